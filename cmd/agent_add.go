@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
 	"github.com/albuquerquesz/gitscribe/internal/config"
 	"github.com/albuquerquesz/gitscribe/internal/secrets"
 	"github.com/albuquerquesz/gitscribe/internal/style"
@@ -11,18 +12,26 @@ import (
 )
 
 var (
-	newAgentName     string
-	newAgentProvider string
-	newAgentModel    string
-	newAgentKey      string
-	newAgentBaseURL  string
+	newAgentName      string
+	newAgentProvider  string
+	newAgentModel     string
+	newAgentKey       string
+	newAgentKeySource string
+	newAgentBaseURL   string
+
+	newAgentAPIVersion string
+	newAgentDeployment string
+	newAgentRegion     string
+	newAgentNoVerify   bool
 )
 
 var agentAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add a new agent profile",
 	Example: `  gs agent add -n my-openai -p openai -m gpt-4
-  gs agent add -n my-groq -p groq -m llama-3.3-70b-versatile -k gsk_xxx`,
+  gs agent add -n my-groq -p groq -m llama-3.3-70b-versatile -k gsk_xxx
+  gs agent add -n work-claude -p anthropic -m claude-sonnet-4-5-20250929 --key-source "command:pass show ai/anthropic"`,
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return addAgent()
 	},
@@ -30,13 +39,19 @@ var agentAddCmd = &cobra.Command{
 
 func init() {
 	agentAddCmd.Flags().StringVarP(&newAgentName, "name", "n", "", "Agent profile name (required)")
-	agentAddCmd.Flags().StringVarP(&newAgentProvider, "provider", "p", "", "Provider: openai, groq, claude, gemini, ollama (required)")
+	agentAddCmd.Flags().StringVarP(&newAgentProvider, "provider", "p", "", "Provider: openai, groq, anthropic, gemini, ollama, or a custom provider (required)")
 	agentAddCmd.Flags().StringVarP(&newAgentModel, "model", "m", "", "Model name (required)")
 	agentAddCmd.Flags().StringVarP(&newAgentKey, "key", "k", "", "API key (will prompt if not provided)")
+	agentAddCmd.Flags().StringVar(&newAgentKeySource, "key-source", "", "Read the key from command:<cmd>, file:<path> or env:<VAR> instead of storing it")
 	agentAddCmd.Flags().StringVar(&newAgentBaseURL, "base-url", "", "Custom base URL (optional)")
+	agentAddCmd.Flags().StringVar(&newAgentAPIVersion, "api-version", "", "Azure OpenAI api-version query parameter")
+	agentAddCmd.Flags().StringVar(&newAgentDeployment, "deployment", "", "Azure OpenAI deployment name (defaults to the model)")
+	agentAddCmd.Flags().StringVar(&newAgentRegion, "region", "", "AWS region for Bedrock (defaults to AWS_REGION)")
+	agentAddCmd.Flags().BoolVar(&newAgentNoVerify, "no-verify", false, "Skip checking the API key with the provider")
 	agentAddCmd.MarkFlagRequired("name")
 	agentAddCmd.MarkFlagRequired("provider")
 	agentAddCmd.MarkFlagRequired("model")
+	agentAddCmd.MarkFlagsMutuallyExclusive("key", "key-source")
 
 	agentCmd.AddCommand(agentAddCmd)
 }
@@ -48,27 +63,33 @@ func addAgent() error {
 	}
 
 	provider := config.AgentProvider(newAgentProvider)
-	validProviders := []config.AgentProvider{
-		config.ProviderOpenAI,
-		config.ProviderGroq,
-		config.ProviderClaude,
-		config.ProviderGemini,
-		config.ProviderOllama,
-		config.ProviderOpenRouter,
-		config.ProviderOpenCode,
-		config.ProviderHackClub,
-	}
-
-	valid := false
-	if found := slices.Contains(validProviders, provider); found {
+	if err := catalog.Configure(cfg); err != nil {
+		return err
+	}
+
+	valid := slices.Contains(config.BuiltinProviders(), provider)
+	if _, err := cfg.GetProvider(newAgentProvider); err == nil {
 		valid = true
 	}
 
 	if !valid {
-		return fmt.Errorf("invalid provider: %s", newAgentProvider)
+		return fmt.Errorf("invalid provider: %s (register custom providers with 'gs provider add')", newAgentProvider)
+	}
+
+	if provider == config.ProviderAzure && newAgentBaseURL == "" {
+		return fmt.Errorf("--base-url is required for Azure agents (e.g. https://<resource>.openai.azure.com)")
+	}
+
+	requiresKey := catalog.RequiresAPIKey(newAgentProvider)
+
+	if newAgentKeySource != "" {
+		if _, _, err := config.ParseKeySource(newAgentKeySource); err != nil {
+			return err
+		}
+		requiresKey = false
 	}
 
-	if newAgentKey == "" {
+	if newAgentKey == "" && requiresKey {
 		prompt := fmt.Sprintf("Enter API key for %s (%s):", newAgentName, provider)
 		key, err := style.Prompt(prompt)
 		if err != nil {
@@ -77,7 +98,7 @@ func addAgent() error {
 		newAgentKey = key
 	}
 
-	if newAgentKey == "" {
+	if newAgentKey == "" && requiresKey {
 		return fmt.Errorf("API key is required")
 	}
 
@@ -86,21 +107,46 @@ func addAgent() error {
 		Provider:    provider,
 		Model:       newAgentModel,
 		BaseURL:     newAgentBaseURL,
+		APIVersion:  newAgentAPIVersion,
+		Deployment:  newAgentDeployment,
+		Region:      newAgentRegion,
 		Enabled:     true,
 		Priority:    1,
 		Temperature: 0.7,
 		MaxTokens:   2048,
 		Timeout:     30,
-		KeyringKey:  secrets.NewAgentKeyManager().GetAgentKeyName(newAgentName),
+		KeySource:   newAgentKeySource,
+	}
+
+	keyMgr := secrets.NewKeyStore()
+	if newAgentKey != "" {
+		agent.KeyringKey = keyMgr.GetAgentKeyName(newAgentName)
+	}
+
+	if err := ensureOllamaReady(agent); err != nil {
+		return err
+	}
+
+	if newAgentKey != "" && !newAgentNoVerify {
+		if err := verifyAPIKey(cfg, agent, newAgentKey); err != nil {
+			return err
+		}
+	}
+
+	if newAgentKeySource != "" && !newAgentNoVerify {
+		if err := verifyKeySource(cfg, agent); err != nil {
+			return err
+		}
 	}
 
 	if err := cfg.AddAgent(agent); err != nil {
 		return err
 	}
 
-	keyMgr := secrets.NewAgentKeyManager()
-	if err := keyMgr.StoreAgentKey(newAgentName, newAgentKey); err != nil {
-		return fmt.Errorf("failed to store API key: %w", err)
+	if newAgentKey != "" {
+		if err := keyMgr.StoreAgentKey(newAgentName, newAgentKey); err != nil {
+			return fmt.Errorf("failed to store API key: %w", err)
+		}
 	}
 
 	if err := cfg.Save(); err != nil {
@@ -30,7 +30,7 @@ func listAllAgents() error {
 	fmt.Println("🤖 Configured AI Agents")
 	fmt.Println(strings.Repeat("─", 50))
 
-	keyMgr := secrets.NewAgentKeyManager()
+	keyMgr := secrets.NewKeyStore()
 
 	for _, agent := range cfg.Agents {
 		defaultMarker := " "
@@ -44,8 +44,10 @@ func listAllAgents() error {
 		}
 
 		keyStatus := "❌"
-		if keyMgr.KeyExists(keyMgr.GetAgentKeyName(agent.Name)) {
-			keyStatus = "✅"
+		if resolved := keyMgr.Resolve(agent); resolved.Key != "" {
+			keyStatus = fmt.Sprintf("✅ (%s)", resolved.Source)
+		} else if resolved.Err != nil {
+			keyStatus = fmt.Sprintf("❌ (%v)", resolved.Err)
 		}
 
 		fmt.Printf("%s %s %s\n", defaultMarker, statusIcon, agent.Name)
@@ -53,6 +55,9 @@ func listAllAgents() error {
 		fmt.Printf("   Model: %s\n", agent.Model)
 		fmt.Printf("   Priority: %d\n", agent.Priority)
 		fmt.Printf("   API Key: %s\n", keyStatus)
+		if agent.KeySource != "" {
+			fmt.Printf("   Key Source: %s\n", agent.KeySource)
+		}
 
 		if agent.BaseURL != "" {
 			fmt.Printf("   Base URL: %s\n", agent.BaseURL)
@@ -44,7 +44,7 @@ func removeAgent(name string) error {
 		return err
 	}
 
-	keyMgr := secrets.NewAgentKeyManager()
+	keyMgr := secrets.NewKeyStore()
 	if err := keyMgr.DeleteAgentKey(name); err != nil {
 		fmt.Printf("Warning: could not remove API key: %v\n", err)
 	}
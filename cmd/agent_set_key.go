@@ -12,13 +12,25 @@ import (
 var agentSetKeyCmd = &cobra.Command{
 	Use:   "set-key [name]",
 	Short: "Set or update API key for an agent",
-	Args:  cobra.ExactArgs(1),
+	Example: `  gs agent set-key my-openai
+  gs agent set-key my-openai --source "command:op read op://Private/OpenAI/credential"
+  gs agent set-key my-openai --source file:~/.config/gs/openai.key`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return setAgentKey(args[0])
 	},
 }
 
+var (
+	setKeyNoVerify bool
+	setKeySource   string
+)
+
 func init() {
+	agentSetKeyCmd.Flags().BoolVar(&setKeyNoVerify, "no-verify", false, "Skip checking the API key with the provider")
+	agentSetKeyCmd.Flags().StringVar(&setKeySource, "source", "", "Read the key from command:<cmd>, file:<path> or env:<VAR> instead of storing it")
+
 	agentCmd.AddCommand(agentSetKeyCmd)
 }
 
@@ -33,6 +45,13 @@ func setAgentKey(name string) error {
 		return err
 	}
 
+	if setKeySource != "" {
+		return setAgentKeySource(cfg, agent, setKeySource)
+	}
+	if agent.KeySource != "" {
+		return fmt.Errorf("agent '%s' reads its key from %s, change it with --source or clear it with 'gs config unset agents.%s.key_source'", name, agent.KeySource, name)
+	}
+
 	prompt := fmt.Sprintf("Enter new API key for %s (%s):", name, agent.Provider)
 	newKey, err := style.Prompt(prompt)
 	if err != nil {
@@ -43,7 +62,13 @@ func setAgentKey(name string) error {
 		return fmt.Errorf("API key cannot be empty")
 	}
 
-	keyMgr := secrets.NewAgentKeyManager()
+	if !setKeyNoVerify {
+		if err := verifyAPIKey(cfg, *agent, newKey); err != nil {
+			return err
+		}
+	}
+
+	keyMgr := secrets.NewKeyStore()
 	if err := keyMgr.StoreAgentKey(name, newKey); err != nil {
 		return fmt.Errorf("failed to store API key: %w", err)
 	}
@@ -51,3 +76,26 @@ func setAgentKey(name string) error {
 	fmt.Printf("✅ API key updated for agent '%s'\n", name)
 	return nil
 }
+
+func setAgentKeySource(cfg *config.Config, agent *config.AgentProfile, source string) error {
+	if _, _, err := config.ParseKeySource(source); err != nil {
+		return err
+	}
+	agent.KeySource = source
+
+	if !setKeyNoVerify {
+		if err := verifyKeySource(cfg, *agent); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✅ Agent '%s' now reads its API key from %s\n", agent.Name, source)
+	if keyMgr := secrets.NewKeyStore(); keyMgr.Has(secrets.ScopeAgent, agent.Name) {
+		style.Info(fmt.Sprintf("The stored key %s is no longer used, remove it with 'gs keys delete %s'", keyMgr.GetAgentKeyName(agent.Name), keyMgr.GetAgentKeyName(agent.Name)))
+	}
+	return nil
+}
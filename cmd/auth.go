@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"sort"
+
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/spf13/cobra"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Log in to providers with OAuth",
+	Long:  "Log in to providers that support OAuth instead of pasting an API key. Tokens are kept in the secrets store and refreshed automatically.",
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+}
+
+func oauthProviders() []string {
+	var names []string
+	for name, pConfig := range catalog.ProviderConfigs {
+		if pConfig.SupportsOAuth2 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/auth"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/providers"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var (
+	authLoginPort      int
+	authLoginNoBrowser bool
+	authLoginTimeout   time.Duration
+	authLoginClient    config.OAuthClient
+)
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login <provider>",
+	Short: "Log in to a provider in the browser",
+	Long: `Start the OAuth authorization-code flow with PKCE. GitScribe opens the
+provider's login page and waits for the redirect on a local callback server,
+then stores the tokens in the secrets store of the active profile.
+
+GitScribe does not ship an OAuth client of its own. Register an OAuth app with
+the provider and pass its client ID and endpoints the first time; they are saved
+in the oauth section of the config and reused for later logins and refreshes.`,
+	Example: `  gs auth login anthropic --client-id <id> --auth-url <url> --token-url <url>
+  gs auth login anthropic --no-browser --port 8765`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return oauthProviders(), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return loginProvider(args[0])
+	},
+}
+
+func init() {
+	authLoginCmd.Flags().IntVar(&authLoginPort, "port", 0, "Port for the local callback server (default: provider default or a free port)")
+	authLoginCmd.Flags().BoolVar(&authLoginNoBrowser, "no-browser", false, "Print the login URL instead of opening a browser")
+	authLoginCmd.Flags().DurationVar(&authLoginTimeout, "timeout", auth.DefaultLoginTimeout, "How long to wait for the browser login")
+	authLoginCmd.Flags().StringVar(&authLoginClient.ClientID, "client-id", "", "Client ID of your OAuth app")
+	authLoginCmd.Flags().StringVar(&authLoginClient.AuthURL, "auth-url", "", "Authorization endpoint of the provider")
+	authLoginCmd.Flags().StringVar(&authLoginClient.TokenURL, "token-url", "", "Token endpoint of the provider")
+	authLoginCmd.Flags().StringSliceVar(&authLoginClient.Scopes, "scope", nil, "Scope to request (repeatable)")
+	authLoginCmd.Flags().StringVar(&authLoginClient.CallbackPath, "callback-path", "", "Redirect path registered for your OAuth app (default /callback)")
+
+	authCmd.AddCommand(authLoginCmd)
+}
+
+func loginProvider(name string) error {
+	if !slices.Contains(oauthProviders(), name) {
+		return fmt.Errorf("%s does not support OAuth login (supported: %s)", name, strings.Join(oauthProviders(), ", "))
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	client, changed := oauthClient(cfg, name)
+	if client.ClientID == "" || client.AuthURL == "" || client.TokenURL == "" {
+		return fmt.Errorf("no OAuth client configured for %s, register an OAuth app with the provider and pass --client-id, --auth-url and --token-url", name)
+	}
+	provider := providers.NewOAuthProvider(client)
+
+	if err := unlockSecrets(); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	token, err := auth.Login(ctx, provider, auth.LoginOptions{
+		Port:    authLoginPort,
+		Timeout: authLoginTimeout,
+		OpenURL: func(authURL string) error {
+			style.Info(fmt.Sprintf("Log in to %s in your browser:", name))
+			fmt.Println(authURL)
+			if !authLoginNoBrowser {
+				if err := auth.OpenBrowser(authURL); err != nil {
+					style.Warning("Could not open a browser, open the URL above manually.")
+				}
+			}
+			fmt.Println()
+			style.Info("Waiting for the login to complete (Ctrl+C to cancel)...")
+			return nil
+		},
+	})
+	switch {
+	case errors.Is(err, auth.ErrPortInUse):
+		return fmt.Errorf("%w, pick another one with --port", err)
+	case errors.Is(err, auth.ErrTimeout):
+		return fmt.Errorf("no login completed within %s", authLoginTimeout)
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("login cancelled")
+	case err != nil:
+		return fmt.Errorf("login failed: %w", err)
+	}
+
+	keys := secrets.NewKeyStore()
+	if err := keys.StoreOAuthToken(name, token); err != nil {
+		return fmt.Errorf("failed to store token: %w", err)
+	}
+	if changed {
+		cfg.SetOAuthClient(client)
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save OAuth client: %w", err)
+		}
+	}
+
+	style.Success(fmt.Sprintf("Logged in to %s (%s)", name, describeToken(token)))
+	if _, source := keys.ResolveProviderKey(name); source != secrets.SourceOAuth {
+		style.Warning(fmt.Sprintf("An API key from %s takes precedence over this login for %s agents.", source, name))
+	}
+	return nil
+}
+
+func oauthClient(cfg *config.Config, name string) (config.OAuthClient, bool) {
+	client := config.OAuthClient{Name: name}
+	if saved, ok := cfg.GetOAuthClient(name); ok {
+		client = *saved
+	}
+	saved := client
+	if authLoginClient.ClientID != "" {
+		client.ClientID = authLoginClient.ClientID
+	}
+	if authLoginClient.AuthURL != "" {
+		client.AuthURL = authLoginClient.AuthURL
+	}
+	if authLoginClient.TokenURL != "" {
+		client.TokenURL = authLoginClient.TokenURL
+	}
+	if len(authLoginClient.Scopes) > 0 {
+		client.Scopes = authLoginClient.Scopes
+	}
+	if authLoginClient.CallbackPath != "" {
+		client.CallbackPath = authLoginClient.CallbackPath
+	}
+	if authLoginPort != 0 {
+		client.Port = authLoginPort
+	}
+	changed := client.ClientID != saved.ClientID || client.AuthURL != saved.AuthURL || client.TokenURL != saved.TokenURL ||
+		!slices.Equal(client.Scopes, saved.Scopes) || client.CallbackPath != saved.CallbackPath || client.Port != saved.Port
+	return client, changed
+}
+
+func describeToken(token *auth.Token) string {
+	parts := []string{"no expiry"}
+	if !token.ExpiresAt.IsZero() {
+		parts[0] = "expires " + formatKeyTime(token.ExpiresAt, "")
+	}
+	if token.RefreshToken != "" {
+		parts = append(parts, "refreshable")
+	}
+	return strings.Join(parts, ", ")
+}
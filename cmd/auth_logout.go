@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var authLogoutCmd = &cobra.Command{
+	Use:          "logout <provider>",
+	Short:        "Remove a stored OAuth login",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return oauthProviders(), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return logoutProvider(args[0])
+	},
+}
+
+func init() {
+	authCmd.AddCommand(authLogoutCmd)
+}
+
+func logoutProvider(name string) error {
+	if err := unlockSecrets(); err != nil {
+		return err
+	}
+
+	err := secrets.NewKeyStore().DeleteOAuthToken(name)
+	if errors.Is(err, secrets.ErrNotFound) {
+		style.Info(fmt.Sprintf("Not logged in to %s.", name))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove login: %w", err)
+	}
+
+	style.Success(fmt.Sprintf("Logged out of %s", name))
+	return nil
+}
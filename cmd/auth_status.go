@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var authStatusCmd = &cobra.Command{
+	Use:          "status",
+	Short:        "Show OAuth logins and when they expire",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showAuthStatus()
+	},
+}
+
+func init() {
+	authCmd.AddCommand(authStatusCmd)
+}
+
+func showAuthStatus() error {
+	if err := unlockSecrets(); err != nil {
+		return err
+	}
+
+	keys := secrets.NewKeyStore()
+	opencode, _ := secrets.LoadOpenCodeAuth()
+
+	fmt.Println("🔐 OAuth Logins")
+	fmt.Println(strings.Repeat("─", 50))
+
+	for _, name := range oauthProviders() {
+		token, err := keys.LoadOAuthToken(name)
+		switch {
+		case err != nil:
+			fmt.Printf("%s %s: not logged in\n", style.ErrorIcon(), name)
+		case token.Expired() && token.RefreshToken == "":
+			fmt.Printf("%s %s: expired, run 'gs auth login %s'\n", style.WarningIcon(), name, name)
+		default:
+			fmt.Printf("%s %s: logged in (%s)\n", style.SuccessIcon(), name, describeToken(token))
+		}
+
+		if opencode.CanRefresh(name) {
+			fmt.Println("   also available from OpenCode auth")
+		}
+		if _, source := keys.ResolveProviderKey(name); source != secrets.SourceNone {
+			fmt.Printf("   agents use: %s\n", source)
+		}
+	}
+	return nil
+}
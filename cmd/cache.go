@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage cached AI responses",
+	Long:  "Inspect and clear the on-disk cache of AI responses keyed by diff, prompt, contexts, agent and model",
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+}
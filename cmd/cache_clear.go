@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/ai"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached AI responses",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return clearCache()
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+func clearCache() error {
+	removed, err := ai.ClearResponseCache()
+	if err != nil {
+		return err
+	}
+	style.Success(fmt.Sprintf("Removed %d cached responses", removed))
+	return nil
+}
@@ -6,13 +6,20 @@ import (
 	"strings"
 
 	"github.com/albuquerquesz/gitscribe/internal/ai"
+	"github.com/albuquerquesz/gitscribe/internal/config"
 	"github.com/albuquerquesz/gitscribe/internal/git"
 	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/albuquerquesz/gitscribe/internal/tui"
 	"github.com/albuquerquesz/gitscribe/internal/version"
 	"github.com/spf13/cobra"
 )
 
 var msg, branch, commitAgent string
+var commitCandidates int
+var commitResume bool
+var commitNoCache bool
+
+const maxCommitCandidates = 5
 
 var commitCmd = &cobra.Command{
 	Use:     "commit [files]",
@@ -28,6 +35,9 @@ func init() {
 	commitCmd.Flags().StringVarP(&msg, "message", "m", "", "The commit message")
 	commitCmd.Flags().StringVarP(&branch, "branch", "b", "", "The branch to push to")
 	commitCmd.Flags().StringVarP(&commitAgent, "agent", "a", "", "The AI agent to use (overrides default)")
+	commitCmd.Flags().BoolVar(&commitNoCache, "no-cache", false, "Always send the request instead of reusing a cached response")
+	commitCmd.Flags().BoolVar(&commitResume, "resume", false, "Resume the last cancelled commit session for this repository")
+	commitCmd.Flags().IntVarP(&commitCandidates, "candidates", "n", 1, fmt.Sprintf("Number of candidate messages to generate (1-%d)", maxCommitCandidates))
 
 	rootCmd.AddCommand(commitCmd)
 }
@@ -36,33 +46,179 @@ func commit(files []string) error {
 	style.GetASCIIName()
 	version.ShowUpdate(v)
 
-	if len(files) == 0 {
+	if commitCandidates < 1 || commitCandidates > maxCommitCandidates {
+		return fmt.Errorf("--candidates must be between 1 and %d", maxCommitCandidates)
+	}
+	ai.SetCacheEnabled(!commitNoCache)
+
+	if len(files) == 0 && !commitResume {
 		files = append(files, ".")
 	}
 
-	if err := git.StageFiles(files); err != nil {
+	if len(files) > 0 {
+		if err := git.StageFiles(files); err != nil {
+			style.Error(err.Error())
+			return err
+		}
+		style.Success("Files staged successfully!")
+	}
+
+	diff, err := git.GetStagedDiff()
+	if err != nil {
 		style.Error(err.Error())
 		return err
 	}
-	style.Success("Files staged successfully!")
 
-	if len(msg) == 0 {
-		diff, err := git.GetStagedDiff()
+	if len(diff) == 0 && len(msg) == 0 {
+		style.Warning("No changes found in stage. Nothing to commit.")
+		return nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		style.Error(fmt.Sprintf("Failed to load config: %v", err))
+		return err
+	}
+
+	projectPath := getProjectPath()
+
+	scanned := len(msg) == 0
+	if scanned {
+		diff, err = guardDiffSecrets(cfg, diff, projectPath)
 		if err != nil {
 			style.Error(err.Error())
 			return err
 		}
-
 		if len(diff) == 0 {
-			style.Warning("No changes found in stage. Nothing to commit.")
+			style.Warning("No changes left in stage. Nothing to commit.")
 			return nil
 		}
+	}
+	var conv *ai.Conversation
+
+	newConversation := func(agent string, useContexts bool) (*ai.Conversation, error) {
+		if !scanned {
+			redacted, err := redactDiffSecrets(cfg, diff, projectPath)
+			if err != nil {
+				return nil, err
+			}
+			diff, scanned = redacted, true
+		}
+		prompt := diff
+		if useContexts {
+			prompt = ai.BuildPromptWithContext(diff, projectPath)
+		}
+		c, err := ai.NewCommitConversation(projectPath, prompt, agent)
+		if err != nil {
+			return nil, err
+		}
+		c.InputHash = ai.HashInput(diff)
+		return c, nil
+	}
+	generate := func(agent string, useContexts, fresh bool) (string, error) {
+		c, err := newConversation(agent, useContexts)
+		if err != nil {
+			return "", err
+		}
+		send := c.Send
+		if fresh {
+			send = c.SendFresh
+		}
+		answer, err := send()
+		if err != nil {
+			return "", err
+		}
+		conv = c
+		_ = ai.SaveConversation(conv)
+		return answer, nil
+	}
+	regenerate := func(agent string, useContexts bool) (string, error) {
+		return generate(agent, useContexts, true)
+	}
+	refine := func(agent, message, feedback string) (string, error) {
+		if conv == nil {
+			c, err := newConversation(agent, true)
+			if err != nil {
+				return "", err
+			}
+			c.Accept(c.Agent, message)
+			conv = c
+		}
+		answer, err := conv.Refine(agent, message, feedback)
+		if err != nil {
+			return "", err
+		}
+		_ = ai.SaveConversation(conv)
+		return answer, nil
+	}
+
+	agentName := commitAgent
+	if commitResume {
+		conv, err = ai.LoadConversation(ai.ConversationCommit, projectPath)
+		if err != nil {
+			style.Error(err.Error())
+			return err
+		}
+		if conv.InputHash != ai.HashInput(diff) {
+			style.Warning("The staged changes differ from the saved session.")
+		}
+		if len(msg) == 0 {
+			msg = conv.Draft
+		}
+		if len(msg) == 0 {
+			msg = conv.LastAnswer()
+		}
+		agentName = conv.Agent
+		style.Info(fmt.Sprintf("Resuming session from %s (%d turns)", conv.UpdatedAt.Format("2006-01-02 15:04"), conv.Turns()))
+	} else if agent, err := resolveCommitAgent(cfg, commitAgent); err == nil {
+		agentName = agent.Name
+		if len(msg) == 0 {
+			warnIfOverBudget(cfg, agent.Name)
+			if err := ensureOllamaReady(*agent); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(msg) == 0 {
+		if err := unlockSecrets(); err != nil {
+			return err
+		}
+	}
+
+	var candidates []tui.Candidate
+	if len(msg) == 0 && commitCandidates > 1 {
+		var results []ai.Candidate
+		err = style.RunWithSpinner(fmt.Sprintf("Generating %d commit messages...", commitCandidates), func() error {
+			var err error
+			results, err = ai.GenerateCandidates(ai.BuildPromptWithContext(diff, projectPath), commitAgent, commitCandidates)
+			return err
+		})
+		if err != nil {
+			style.Error(fmt.Sprintf("Error generating message with AI: %v", err))
+			return err
+		}
+		for _, c := range results {
+			candidates = append(candidates, tui.Candidate{Agent: c.Agent, Model: c.Model, Message: c.Message})
+		}
+		if len(candidates) < commitCandidates {
+			style.Warning(fmt.Sprintf("Only %d distinct messages were generated.", len(candidates)))
+		}
+		style.Success("Messages generated!")
+		msg = candidates[0].Message
+		agentName = candidates[0].Agent
+
+		if conv, err = newConversation(agentName, true); err == nil {
+			conv.Accept(agentName, msg)
+			_ = ai.SaveConversation(conv)
+		}
+	}
 
+	if len(msg) == 0 {
 		var result string
 		err = style.RunWithSpinner("Generating commit message...", func() error {
 			var err error
-			enhancedDiff := ai.BuildPromptWithContext(diff, getProjectPath())
-			result, err = ai.SendPrompt(enhancedDiff, commitAgent)
+			result, err = generate(commitAgent, true, false)
 			return err
 		})
 		if err != nil {
@@ -70,13 +226,53 @@ func commit(files []string) error {
 			return err
 		}
 		style.Success("Message generated!")
+		if conv != nil && conv.FromCache {
+			style.Info("Reused a cached response, no request was made. Press Ctrl+R to regenerate or pass --no-cache.")
+		}
 		msg = result
 	}
 
-	action, finalMsg := style.ShowCommitPrompt(msg)
-	if action == "cancel" {
+	stagedFiles, _ := git.GetStagedFiles()
+	var agentNames []string
+	for _, agent := range cfg.Agents {
+		if agent.Enabled {
+			agentNames = append(agentNames, agent.Name)
+		}
+	}
+
+	var contexts []string
+	if cm, err := config.LoadContexts(); err == nil {
+		for _, ctx := range cm.ListContexts(projectPath) {
+			contexts = append(contexts, ctx.Text)
+		}
+	}
+
+	action, finalMsg, err := tui.RunComposer(tui.ComposerOptions{
+		Message:     msg,
+		Diff:        diff,
+		Files:       stagedFiles,
+		Agents:      agentNames,
+		Agent:       agentName,
+		Candidates:  candidates,
+		Contexts:    contexts,
+		UseContexts: true,
+		Cached:      conv != nil && conv.FromCache,
+		Regenerate:  regenerate,
+		Refine:      refine,
+	})
+	if err != nil {
+		style.Error(fmt.Sprintf("Failed to open commit composer: %v", err))
+		return err
+	}
+	if action == tui.ComposerCancel {
 		fmt.Println()
 		fmt.Println("Commit cancelled")
+		if conv != nil {
+			conv.Draft = finalMsg
+			if err := ai.SaveConversation(conv); err == nil {
+				style.Info("Run 'gs commit --resume' to pick up where you left off.")
+			}
+		}
 		return nil
 	}
 	msg = finalMsg
@@ -86,6 +282,10 @@ func commit(files []string) error {
 	}
 	style.Success("Commit successful!")
 
+	if conv != nil {
+		_ = ai.DeleteConversation(ai.ConversationCommit, projectPath)
+	}
+
 	targetBranch := branch
 	if targetBranch == "" {
 		current, err := git.GetCurrentBranch()
@@ -96,7 +296,7 @@ func commit(files []string) error {
 		targetBranch = current
 	}
 
-	err := style.RunWithSpinner("Pushing to remote...", func() error {
+	err = style.RunWithSpinner("Pushing to remote...", func() error {
 		return git.Push(targetBranch)
 	})
 	if err != nil {
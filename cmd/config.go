@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read, change and maintain the configuration",
+	Long: `Read and change config.yaml with dotted keys such as global.max_retries or
+agents.<name>.temperature, edit it in your editor, move it between machines with
+export and import, and validate or migrate config.yaml and contexts.json.`,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in your editor",
+	Long:  "Open config.yaml in $VISUAL or $EDITOR and validate it when the editor exits",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return editConfig()
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configEditCmd)
+}
+
+func editConfig() error {
+	path, err := config.GetConfigPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := config.DefaultConfig().Save(); err != nil {
+			return fmt.Errorf("failed to create config file: %w", err)
+		}
+	}
+
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	for {
+		if err := runEditor(path); err != nil {
+			return fmt.Errorf("failed to run editor: %w", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+
+		issues := config.Validate(data)
+		for _, issue := range issues {
+			if issue.Severity == config.SeverityError {
+				style.Error(issue.String())
+			} else {
+				style.Warning(issue.String())
+			}
+		}
+		if !config.HasErrors(issues) {
+			style.Success("Config saved")
+			return nil
+		}
+
+		if !style.ConfirmAction("The config has errors. Edit it again?") {
+			if err := os.WriteFile(path, original, 0600); err != nil {
+				return fmt.Errorf("failed to restore config file: %w", err)
+			}
+			style.Warning("Changes discarded, the previous config was restored")
+			return nil
+		}
+	}
+}
+
+func runEditor(path string) error {
+	editor := "vi"
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if value := strings.TrimSpace(os.Getenv(env)); value != "" {
+			editor = value
+			break
+		}
+	}
+
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
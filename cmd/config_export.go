@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	configExportOutput   string
+	configExportContexts bool
+)
+
+var configExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the configuration without secrets",
+	Long: `Write the configuration to a file (or stdout) so it can be imported on another
+machine. API keys are never exported, and secret headers and proxy credentials are
+left out. The keys that must be set on the target machine are listed in the file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return exportConfig()
+	},
+}
+
+func init() {
+	configExportCmd.Flags().StringVarP(&configExportOutput, "output", "o", "", "File to write (default: stdout)")
+	configExportCmd.Flags().BoolVar(&configExportContexts, "contexts", false, "Include project contexts")
+
+	configCmd.AddCommand(configExportCmd)
+}
+
+func exportConfig() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	catalog.RegisterCustomProviders(cfg.Providers)
+
+	bundle, err := config.NewExportBundle(cfg)
+	if err != nil {
+		return err
+	}
+	for _, agent := range cfg.Agents {
+		if !catalog.RequiresAPIKey(string(agent.Provider)) {
+			continue
+		}
+		if agent.KeySource != "" {
+			bundle.Require(config.RequiredSecret{
+				Path:        "agents." + agent.Name,
+				Agent:       agent.Name,
+				Provider:    string(agent.Provider),
+				Description: fmt.Sprintf("API key read from %s, make sure it works on this machine", agent.KeySource),
+			})
+			continue
+		}
+		bundle.Require(config.RequiredSecret{
+			Path:        "agents." + agent.Name,
+			Agent:       agent.Name,
+			Provider:    string(agent.Provider),
+			Description: fmt.Sprintf("API key, set it with 'gs agent set-key %s' or export %s", agent.Name, secrets.EnvVarForProvider(agent.Provider)),
+		})
+	}
+
+	if configExportContexts {
+		cm, err := config.LoadContexts()
+		if err != nil {
+			return fmt.Errorf("failed to load contexts: %w", err)
+		}
+		bundle.Contexts = cm.Contexts
+	}
+
+	data, err := yaml.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("failed to marshal export: %w", err)
+	}
+
+	if configExportOutput == "" {
+		fmt.Print(string(data))
+	} else {
+		if err := os.WriteFile(configExportOutput, data, 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", configExportOutput, err)
+		}
+		style.Success(fmt.Sprintf("Exported config to %s", configExportOutput))
+	}
+
+	if len(bundle.RequiredSecrets) > 0 {
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, "Secrets to set on the target machine:")
+		for _, s := range bundle.RequiredSecrets {
+			fmt.Fprintf(os.Stderr, "  • %s: %s\n", s.Path, s.Description)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configGetCmd = &cobra.Command{
+	Use:          "get [key]",
+	Short:        "Print the value of a config key",
+	Long:         "Print the value of a dotted config key, for example global.max_retries or agents.groq-default.temperature",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getConfigValue(args[0])
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+}
+
+func getConfigValue(key string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	value, err := cfg.Get(key)
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	return nil
+}
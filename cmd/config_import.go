@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	configImportMerge         bool
+	configImportAllowCommands bool
+)
+
+var configImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import a configuration exported with gs config export",
+	Long: `Replace the configuration with an exported one, or with --merge add only the
+agents and providers that do not exist yet. The current config is backed up first.
+
+Agents that read their API key with a command: key source are listed before the
+import. They are kept only after confirmation or with --allow-commands; otherwise
+their key_source is dropped.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importConfig(args[0])
+	},
+}
+
+func init() {
+	configImportCmd.Flags().BoolVar(&configImportMerge, "merge", false, "Only add agents and providers that are missing")
+	configImportCmd.Flags().BoolVar(&configImportAllowCommands, "allow-commands", false, "Keep command: key sources from the imported file without asking")
+
+	configCmd.AddCommand(configImportCmd)
+}
+
+func importConfig(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	bundle, err := config.LoadExportBundle(data)
+	if err != nil {
+		style.Error(err.Error())
+		return err
+	}
+
+	raw, err := yaml.Marshal(&bundle.Config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal imported config: %w", err)
+	}
+	if issues := config.Validate(raw); config.HasErrors(issues) {
+		for _, issue := range issues {
+			if issue.Severity == config.SeverityError {
+				style.Error(issue.String())
+			}
+		}
+		return fmt.Errorf("the imported config is invalid")
+	}
+
+	reviewKeyCommands(bundle)
+
+	backup, err := config.BackupConfigFile()
+	if err != nil {
+		return err
+	}
+	if backup != "" {
+		style.Info(fmt.Sprintf("Backed up the current config to %s", backup))
+	}
+
+	cfg := &bundle.Config
+	if configImportMerge {
+		cfg, err = config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		added := cfg.Merge(&bundle.Config)
+		if len(added) == 0 {
+			style.Info("Nothing to merge, every agent and provider already exists")
+		} else {
+			style.Success(fmt.Sprintf("Added %s", strings.Join(added, ", ")))
+		}
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if len(bundle.Contexts) > 0 {
+		if err := importContexts(bundle.Contexts); err != nil {
+			style.Warning(fmt.Sprintf("Failed to import contexts: %v", err))
+		}
+	}
+
+	style.Success(fmt.Sprintf("Imported config from %s", file))
+	printRequiredSecrets(cfg, bundle.RequiredSecrets)
+	return nil
+}
+
+func importContexts(contexts map[string][]config.ContextEntry) error {
+	cm, err := config.LoadContexts()
+	if err != nil {
+		return err
+	}
+	for path, entries := range contexts {
+		if len(cm.Contexts[path]) == 0 {
+			cm.Contexts[path] = entries
+		}
+	}
+	return cm.Save()
+}
+
+func reviewKeyCommands(bundle *config.ExportBundle) {
+	cfg := &bundle.Config
+	var indexes []int
+	for i, agent := range cfg.Agents {
+		if isKeyCommand(agent.KeySource) {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("⚠️  Commands that will run to read API keys")
+	fmt.Println(strings.Repeat("─", 50))
+	for _, i := range indexes {
+		_, command, _ := config.ParseKeySource(cfg.Agents[i].KeySource)
+		fmt.Printf("  %s %s: %s\n", style.WarningIcon(), cfg.Agents[i].Name, command)
+	}
+	fmt.Println()
+
+	if configImportAllowCommands {
+		return
+	}
+	if term.IsTerminal(int(os.Stdin.Fd())) && style.ConfirmAction("Keep these commands?") {
+		return
+	}
+
+	dropped := make(map[string]bool)
+	for _, i := range indexes {
+		cfg.Agents[i].KeySource = ""
+		dropped[cfg.Agents[i].Name] = true
+	}
+	for i, s := range bundle.RequiredSecrets {
+		if dropped[s.Agent] && s.Path == "agents."+s.Agent {
+			bundle.RequiredSecrets[i].Description = fmt.Sprintf("API key, set it with 'gs agent set-key %s' or export %s", s.Agent, secrets.EnvVarForProvider(config.AgentProvider(s.Provider)))
+		}
+	}
+	style.Warning(fmt.Sprintf("Dropped %d command key source(s). Re-run with --allow-commands to keep them.", len(indexes)))
+}
+
+func isKeyCommand(ref string) bool {
+	if ref == "" {
+		return false
+	}
+	kind, _, err := config.ParseKeySource(ref)
+	return err == nil && kind == config.KeySourceCommand
+}
+
+func printRequiredSecrets(cfg *config.Config, required []config.RequiredSecret) {
+	if len(required) == 0 {
+		return
+	}
+
+	keys := secrets.NewKeyStore()
+	fmt.Println()
+	fmt.Println("Secrets to set on this machine:")
+	for _, s := range required {
+		if s.Agent != "" && s.Path == "agents."+s.Agent {
+			if agent, err := cfg.GetAgentByName(s.Agent); err == nil && !isKeyCommand(agent.KeySource) {
+				if apiKey, _ := keys.ResolveAPIKey(*agent); apiKey != "" {
+					fmt.Printf("  %s %s: API key already available\n", style.SuccessIcon(), s.Path)
+					continue
+				}
+			}
+		}
+		fmt.Printf("  %s %s: %s\n", style.WarningIcon(), s.Path, s.Description)
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all config keys that have a value",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listConfig()
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configListCmd)
+}
+
+func listConfig() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	fmt.Println("⚙️  Configuration")
+	fmt.Println(strings.Repeat("─", 50))
+
+	for _, kv := range cfg.List() {
+		value := kv.Value
+		if kv.Secret {
+			value = style.StringMask(value)
+		}
+		fmt.Printf("%s = %s\n", kv.Key, value)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var configMigrateDryRun bool
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade config.yaml and contexts.json to the current schema",
+	Long: `Apply pending schema migrations to config.yaml and contexts.json one step
+at a time. The original files are backed up next to them before they are rewritten.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return migrateConfig()
+	},
+}
+
+func init() {
+	configMigrateCmd.Flags().BoolVar(&configMigrateDryRun, "dry-run", false, "Show the pending migrations and the result without writing anything")
+
+	configCmd.AddCommand(configMigrateCmd)
+}
+
+func migrateConfig() error {
+	for _, migrate := range []func(bool) (*config.MigrationResult, error){config.MigrateConfigFile, config.MigrateContextsFile} {
+		result, err := migrate(configMigrateDryRun)
+		if err != nil {
+			style.Error(err.Error())
+			return err
+		}
+		printMigrationResult(result)
+	}
+	return nil
+}
+
+func printMigrationResult(result *config.MigrationResult) {
+	if result.Data == nil {
+		style.Info(fmt.Sprintf("%s does not exist, nothing to migrate", result.Path))
+		return
+	}
+	if len(result.Applied) == 0 {
+		style.Success(fmt.Sprintf("%s is up to date", result.Path))
+		return
+	}
+
+	if configMigrateDryRun {
+		style.Info(fmt.Sprintf("%s would be migrated:", result.Path))
+	} else {
+		style.Success(fmt.Sprintf("Migrated %s:", result.Path))
+	}
+	for _, m := range result.Applied {
+		fmt.Printf("  %s → %s  %s\n", m.From, m.To, m.Description)
+	}
+
+	if configMigrateDryRun {
+		fmt.Println()
+		fmt.Println(string(result.Data))
+		return
+	}
+	style.Info(fmt.Sprintf("Backup saved to %s", result.BackupPath))
+}
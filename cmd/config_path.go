@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the path of the config file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.GetConfigPath()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configPathCmd)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configSetCmd = &cobra.Command{
+	Use:   "set [key] [value]",
+	Short: "Set a config key",
+	Long: `Set a dotted config key. Values are checked against the field type (bool,
+integer, number or string) and the result is validated before it is saved.
+
+Examples:
+  gs config set global.max_retries 5
+  gs config set agents.groq-default.temperature 0.3
+  gs config set global.custom_headers.X-Team platform`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setConfigValue(args[0], args[1])
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configSetCmd)
+}
+
+func setConfigValue(key, value string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	path, err := cfg.Set(key, value)
+	if err != nil {
+		style.Error(err.Error())
+		return err
+	}
+	if err := saveConfigChange(cfg, path); err != nil {
+		return err
+	}
+
+	style.Success(fmt.Sprintf("%s = %s", key, value))
+	return nil
+}
+
+func saveConfigChange(cfg *config.Config, path string) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	for _, issue := range config.Validate(data) {
+		if issue.Severity != config.SeverityError {
+			continue
+		}
+		if issue.Path == path || strings.HasPrefix(issue.Path, path+".") || strings.HasPrefix(path, issue.Path+".") {
+			style.Error(issue.String())
+			return fmt.Errorf("invalid value for %s", path)
+		}
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
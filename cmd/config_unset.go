@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var configUnsetCmd = &cobra.Command{
+	Use:          "unset [key]",
+	Short:        "Reset a config key to its empty value",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return unsetConfigValue(args[0])
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configUnsetCmd)
+}
+
+func unsetConfigValue(key string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	path, err := cfg.Unset(key)
+	if err != nil {
+		style.Error(err.Error())
+		return err
+	}
+	if err := saveConfigChange(cfg, path); err != nil {
+		return err
+	}
+
+	style.Success(fmt.Sprintf("Unset %s", key))
+	return nil
+}
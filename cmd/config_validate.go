@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var configValidateCmd = &cobra.Command{
+	Use:          "validate [file]",
+	Short:        "Check config.yaml (or the given file) and contexts.json for errors",
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return validateConfig(args)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
+
+func validateConfig(args []string) error {
+	type target struct {
+		path     string
+		validate func([]byte) []config.Issue
+	}
+
+	var targets []target
+	if len(args) == 1 {
+		targets = append(targets, target{args[0], config.Validate})
+	} else {
+		path, err := config.GetConfigPath()
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target{path, config.Validate})
+		if cm, err := config.ContextsPath(); err == nil {
+			targets = append(targets, target{cm, config.ValidateContexts})
+		}
+	}
+
+	errorCount := 0
+	for _, t := range targets {
+		data, err := os.ReadFile(t.path)
+		if os.IsNotExist(err) && len(args) == 0 {
+			style.Info(fmt.Sprintf("%s does not exist, nothing to validate", t.path))
+			continue
+		}
+		if err != nil {
+			style.Error(fmt.Sprintf("Failed to read %s: %v", t.path, err))
+			errorCount++
+			continue
+		}
+
+		issues := t.validate(data)
+		if len(issues) == 0 {
+			style.Success(fmt.Sprintf("%s is valid", t.path))
+			continue
+		}
+
+		name := filepath.Base(t.path)
+		for _, issue := range issues {
+			line := fmt.Sprintf("%s: %s", name, issue)
+			if issue.Severity == config.SeverityError {
+				style.Error(line)
+				errorCount++
+			} else {
+				style.Warning(line)
+			}
+		}
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("found %d error(s)", errorCount)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/doctor"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var doctorJSON bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose your environment and configuration",
+	Long: `Check git, the current repository, the config file, the default agent,
+API keys, the system keyring, gh/glab, agent endpoints and the model catalog.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDoctor()
+	},
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Print the report as JSON")
+
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor() error {
+	_ = unlockSecrets()
+
+	var report doctor.Report
+	if doctorJSON {
+		report = doctor.Run(context.Background())
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	} else {
+		_ = style.RunWithSpinner("Running diagnostics...", func() error {
+			report = doctor.Run(context.Background())
+			return nil
+		})
+		printDoctorReport(report)
+	}
+
+	if report.Failed() {
+		return fmt.Errorf("%d check(s) failed", report.Count(doctor.StatusFail))
+	}
+	return nil
+}
+
+func printDoctorReport(report doctor.Report) {
+	fmt.Println("🩺 Doctor")
+	fmt.Println(strings.Repeat("─", 50))
+
+	width := 0
+	for _, c := range report.Checks {
+		width = max(width, len(c.Name))
+	}
+
+	for _, c := range report.Checks {
+		fmt.Printf("%s %-*s  %s\n", doctorIcon(c.Status), width, c.Name, c.Message)
+		if c.Hint != "" && c.Status != doctor.StatusPass {
+			fmt.Printf("  %-*s  %s\n", width, "", style.DimStyle.Render("→ "+c.Hint))
+		}
+	}
+
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("%d passed, %d warnings, %d failed\n",
+		report.Count(doctor.StatusPass), report.Count(doctor.StatusWarn), report.Count(doctor.StatusFail))
+}
+
+func doctorIcon(status doctor.Status) string {
+	switch status {
+	case doctor.StatusPass:
+		return style.SuccessIcon()
+	case doctor.StatusWarn:
+		return style.WarningIcon()
+	default:
+		return style.ErrorIcon()
+	}
+}
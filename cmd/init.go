@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
 	"github.com/albuquerquesz/gitscribe/internal/config"
 	"github.com/albuquerquesz/gitscribe/internal/secrets"
 	"github.com/albuquerquesz/gitscribe/internal/style"
@@ -33,7 +35,8 @@ func init() {
 
 func runInit() error {
 	fmt.Println("Welcome to GitScribe!")
-	fmt.Println("Let's set up your AI provider.\n")
+	fmt.Println("Let's set up your AI provider.")
+	fmt.Println()
 
 	auth, err := secrets.LoadOpenCodeAuth()
 	if err == nil && auth != nil && len(auth) > 0 {
@@ -48,16 +51,21 @@ func setupFromOpenCode(auth secrets.OpenCodeAuth) error {
 
 	providers := auth.ListProviders()
 	for _, p := range providers {
-		if key, ok := auth.GetAPIKey(p); ok {
-			masked := maskKey(key)
-			status := "ready"
-			if auth.IsTokenExpired(p) {
-				status = "expired"
-			} else if auth.IsTokenExpiringSoon(p, 24*time.Hour) {
-				status = "expiring soon"
+		expired := auth.IsTokenExpired(p)
+		key, ok := secrets.OpenCodeAccessToken(context.Background(), p)
+		if !ok {
+			if expired && auth.CanRefresh(p) {
+				fmt.Printf("  %s %s [expired, refresh failed]\n", style.WarningIcon(), p)
 			}
-			fmt.Printf("  %s %s (%s) [%s]\n", style.SuccessIcon(), p, masked, status)
+			continue
+		}
+		status := "ready"
+		if expired {
+			status = "refreshed"
+		} else if auth.IsTokenExpiringSoon(p, 24*time.Hour) {
+			status = "expiring soon"
 		}
+		fmt.Printf("  %s %s (%s) [%s]\n", style.SuccessIcon(), p, maskKey(key), status)
 	}
 	fmt.Println()
 
@@ -71,9 +79,10 @@ func setupFromOpenCode(auth secrets.OpenCodeAuth) error {
 
 func setupManual() error {
 	fmt.Println("No OpenCode authentication found.")
-	fmt.Println("Please configure your API key manually.\n")
+	fmt.Println("Please configure your API key manually.")
+	fmt.Println()
 
-	providers := []string{"anthropic", "openai", "groq", "openrouter"}
+	providers := []string{"anthropic", "openai", "groq", "openrouter", "ollama"}
 
 	fmt.Println("Available providers:")
 	for i, p := range providers {
@@ -81,8 +90,9 @@ func setupManual() error {
 	}
 	fmt.Println()
 
-	fmt.Println("Run 'gs auth set-key -p <provider>' to configure your API key.")
-	fmt.Println("Example: gs auth set-key -p anthropic")
+	fmt.Println("Run 'gs models' to pick a model and store the API key for its provider.")
+	fmt.Println("Or add an agent with its own key: gs agent add -n claude -p anthropic -m claude-sonnet-4-5-20250929")
+	fmt.Println("For a local Ollama model no key is needed: gs agent add -n local -p ollama -m llama3.2")
 
 	return nil
 }
@@ -109,15 +119,11 @@ func createDefaultAgent(provider string, source string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
-
-	modelMap := map[string]string{
-		"anthropic":  "claude-3-5-sonnet-20241022",
-		"openai":     "gpt-4o",
-		"groq":       "llama-3.3-70b-versatile",
-		"openrouter": "anthropic/claude-3.5-sonnet",
+	if err := catalog.Configure(cfg); err != nil {
+		style.Warning(err.Error())
 	}
 
-	model := modelMap[provider]
+	model := catalog.DefaultModel(provider)
 	if model == "" {
 		model = "default"
 	}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage stored API keys",
+	Long:  "Inspect stored API keys and move them between the system keyring and the encrypted secrets file",
+}
+
+func init() {
+	rootCmd.AddCommand(keysCmd)
+}
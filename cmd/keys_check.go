@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/albuquerquesz/gitscribe/internal/agents"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var keysTestCmd = &cobra.Command{
+	Use:          "test [key]",
+	Aliases:      []string{"check"},
+	Short:        "Check that keys are accepted by their provider",
+	Long:         "Verify each key with its provider, using the model of the agents that rely on it. Without an argument every key from 'gs keys list' is tested.",
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := ""
+		if len(args) > 0 {
+			query = args[0]
+		}
+		return testKeys(query)
+	},
+}
+
+func init() {
+	keysCmd.AddCommand(keysTestCmd)
+}
+
+type keyTestResult struct {
+	entry secrets.KeyEntry
+	err   error
+}
+
+func testKeys(query string) error {
+	cfg, _, entries, err := loadKeyInventory()
+	if err != nil {
+		return err
+	}
+
+	if query != "" {
+		entry, ok := secrets.FindKeyEntry(entries, cfg, query)
+		if !ok {
+			return fmt.Errorf("key not found: %s (see 'gs keys list')", query)
+		}
+		entries = []secrets.KeyEntry{entry}
+	}
+	if len(entries) == 0 {
+		style.Info("No API keys to test.")
+		return nil
+	}
+
+	factory := agents.NewFactory(cfg)
+	results := make([]keyTestResult, len(entries))
+	_ = style.RunWithSpinner("Testing keys...", func() error {
+		ctx := context.Background()
+		var wg sync.WaitGroup
+		for i, entry := range entries {
+			wg.Add(1)
+			go func(i int, entry secrets.KeyEntry) {
+				defer wg.Done()
+				results[i] = keyTestResult{entry: entry}
+				if entry.Provider == "" {
+					results[i].err = fmt.Errorf("no agent or provider to test it against")
+					return
+				}
+				results[i].err = validateKeyEntry(ctx, factory, cfg, entry)
+			}(i, entry)
+		}
+		wg.Wait()
+		return nil
+	})
+
+	failed := 0
+	for _, r := range results {
+		var verr *agents.KeyValidationError
+		switch {
+		case r.err == nil:
+			fmt.Printf("  %s %s (%s): accepted by %s\n", style.SuccessIcon(), r.entry.Ref, r.entry.Source, r.entry.Provider)
+		case errors.Is(r.err, agents.ErrListingUnsupported), errors.As(r.err, &verr) && !verr.Fatal():
+			fmt.Printf("  %s %s (%s): %v\n", style.WarningIcon(), r.entry.Ref, r.entry.Source, r.err)
+		default:
+			failed++
+			fmt.Printf("  %s %s (%s): %v\n", style.ErrorIcon(), r.entry.Ref, r.entry.Source, r.err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d key(s) failed", failed)
+	}
+	return nil
+}
+
+func validateKeyEntry(ctx context.Context, factory *agents.Factory, cfg *config.Config, entry secrets.KeyEntry) error {
+	for _, name := range entry.Agents {
+		if agent, err := cfg.GetAgentByName(name); err == nil {
+			return factory.ValidateKey(ctx, *agent, entry.Value)
+		}
+	}
+	if entry.Scope == secrets.ScopeAgent {
+		if agent, err := cfg.GetAgentByName(entry.Name); err == nil {
+			return factory.ValidateKey(ctx, *agent, entry.Value)
+		}
+	}
+	return factory.ValidateProviderKey(ctx, entry.Provider, entry.Value)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var keysDeleteForce bool
+
+var keysDeleteCmd = &cobra.Command{
+	Use:          "delete [key]",
+	Aliases:      []string{"rm"},
+	Short:        "Delete a stored key",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return deleteKey(args[0])
+	},
+}
+
+func init() {
+	keysDeleteCmd.Flags().BoolVarP(&keysDeleteForce, "force", "f", false, "Delete without confirmation")
+
+	keysCmd.AddCommand(keysDeleteCmd)
+}
+
+func deleteKey(query string) error {
+	cfg, store, entries, err := loadKeyInventory()
+	if err != nil {
+		return err
+	}
+
+	entry, ok := secrets.FindKeyEntry(entries, cfg, query)
+	if !ok {
+		return fmt.Errorf("key not found: %s (see 'gs keys list')", query)
+	}
+	switch entry.Source {
+	case string(secrets.SourceEnv):
+		return fmt.Errorf("%s comes from the environment, unset %s instead", entry.Ref, entry.Name)
+	case string(secrets.SourceOpenCode):
+		return fmt.Errorf("%s comes from OpenCode auth, log out of %s in OpenCode instead", entry.Ref, entry.Name)
+	case string(secrets.SourceCommand), string(secrets.SourceFile):
+		return fmt.Errorf("%s is an external key source, remove key_source from the agents using it instead", entry.Ref)
+	}
+
+	if len(entry.Agents) > 0 {
+		style.Warning(fmt.Sprintf("Used by: %s", strings.Join(entry.Agents, ", ")))
+	}
+	if !keysDeleteForce && !style.ConfirmAction(fmt.Sprintf("Delete %s from %s?", entry.Ref, entry.Source)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	if err := store.Delete(entry.Ref); err != nil {
+		return fmt.Errorf("failed to delete key: %w", err)
+	}
+
+	style.Success(fmt.Sprintf("Deleted %s", entry.Ref))
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var keysListCmd = &cobra.Command{
+	Use:          "list",
+	Aliases:      []string{"ls"},
+	Short:        "List stored keys and the keys found in the environment or OpenCode",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listKeys()
+	},
+}
+
+func init() {
+	keysCmd.AddCommand(keysListCmd)
+}
+
+func loadKeyInventory() (*config.Config, *secrets.KeyStore, []secrets.KeyEntry, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := unlockSecrets(); err != nil {
+		return nil, nil, nil, err
+	}
+	store := secrets.NewKeyStore()
+	entries, err := store.Inventory(cfg)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	return cfg, store, entries, nil
+}
+
+func listKeys() error {
+	_, _, entries, err := loadKeyInventory()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🔑 API Keys")
+	fmt.Println(strings.Repeat("─", 50))
+
+	if len(entries) == 0 {
+		style.Info("No API keys found. Add one with 'gs agent set-key <agent>' or 'gs models'.")
+		return nil
+	}
+
+	for _, entry := range entries {
+		printKeyEntry(entry, false)
+		fmt.Println()
+	}
+	return nil
+}
+
+func printKeyEntry(entry secrets.KeyEntry, reveal bool) {
+	fmt.Println(entry.Ref)
+	if entry.Provider != "" {
+		fmt.Printf("   Provider: %s\n", entry.Provider)
+	}
+	fmt.Printf("   Source: %s\n", entry.Source)
+	value := style.StringMask(entry.Value)
+	if reveal {
+		value = entry.Value
+	}
+	fmt.Printf("   Value: %s\n", value)
+	if entry.Stored() {
+		fmt.Printf("   Updated: %s\n", formatKeyTime(entry.UpdatedAt, "unknown"))
+		fmt.Printf("   Last used: %s\n", formatKeyTime(entry.LastUsed, "never"))
+	}
+	agents := "-"
+	if len(entry.Agents) > 0 {
+		agents = strings.Join(entry.Agents, ", ")
+	}
+	fmt.Printf("   Used by: %s\n", agents)
+}
+
+func formatKeyTime(t time.Time, zero string) string {
+	if t.IsZero() {
+		return zero
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var (
+	keysMoveTo    string
+	keysMoveFrom  string
+	keysMoveKeep  bool
+	keysMoveForce bool
+)
+
+var keysMoveCmd = &cobra.Command{
+	Use:   "move",
+	Short: "Move stored keys to another secrets backend",
+	Long: `Move the keys of the active profile between the system keyring and the
+encrypted secrets file, then make the destination the configured backend.`,
+	Example: `  gs keys move --to file
+  gs keys move --to keyring --keep`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return moveKeys()
+	},
+}
+
+func init() {
+	keysMoveCmd.Flags().StringVar(&keysMoveTo, "to", "", "Destination backend (keyring or file)")
+	keysMoveCmd.Flags().StringVar(&keysMoveFrom, "from", "", "Source backend (defaults to the active one)")
+	keysMoveCmd.Flags().BoolVar(&keysMoveKeep, "keep", false, "Copy keys and leave them in the source backend")
+	keysMoveCmd.Flags().BoolVarP(&keysMoveForce, "force", "f", false, "Overwrite keys that already exist in the destination")
+	_ = keysMoveCmd.MarkFlagRequired("to")
+
+	keysCmd.AddCommand(keysMoveCmd)
+}
+
+func moveKeys() error {
+	to, err := secrets.BackendByName(keysMoveTo)
+	if err != nil {
+		return err
+	}
+
+	from, _ := secrets.ActiveBackend()
+	if keysMoveFrom != "" {
+		if from, err = secrets.BackendByName(keysMoveFrom); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	service := secrets.ServiceForProfile(config.ActiveProfile())
+	keys, err := secrets.CandidateKeys(from, service, cfg)
+	if err != nil {
+		return err
+	}
+
+	result, err := secrets.MoveKeys(service, from, to, keys, keysMoveKeep, keysMoveForce)
+	if result != nil {
+		for _, key := range result.Moved {
+			fmt.Printf("  %s %s\n", style.SuccessIcon(), key)
+		}
+		for _, key := range result.Conflicts {
+			fmt.Printf("  %s %s: a different key already exists in %s (use --force to overwrite)\n", style.WarningIcon(), key, to.Name())
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	verb := "Moved"
+	if keysMoveKeep {
+		verb = "Copied"
+	}
+	style.Success(fmt.Sprintf("%s %d key(s) from %s to %s", verb, len(result.Moved), from.Name(), to.Name()))
+
+	if cfg.Global.SecretsBackend != to.Name() {
+		cfg.Global.SecretsBackend = to.Name()
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		style.Info(fmt.Sprintf("Set global.secrets_backend to %s", to.Name()))
+	}
+	if env := os.Getenv(secrets.BackendEnvVar); env != "" && env != to.Name() {
+		style.Warning(fmt.Sprintf("%s=%s still overrides the configured backend", secrets.BackendEnvVar, env))
+	}
+	secrets.SetBackend(to, "global.secrets_backend")
+	return nil
+}
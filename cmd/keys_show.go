@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/spf13/cobra"
+)
+
+var keysShowReveal bool
+
+var keysShowCmd = &cobra.Command{
+	Use:   "show [key]",
+	Short: "Show one key, by reference or by the agent that uses it",
+	Example: `  gs keys show provider:groq
+  gs keys show agent:work-claude:api-key
+  gs keys show env:OPENAI_API_KEY
+  gs keys show groq-default`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showKey(args[0])
+	},
+}
+
+func init() {
+	keysShowCmd.Flags().BoolVar(&keysShowReveal, "reveal", false, "Print the full key instead of a masked value")
+
+	keysCmd.AddCommand(keysShowCmd)
+}
+
+func showKey(query string) error {
+	cfg, _, entries, err := loadKeyInventory()
+	if err != nil {
+		return err
+	}
+
+	entry, ok := secrets.FindKeyEntry(entries, cfg, query)
+	if !ok {
+		return fmt.Errorf("key not found: %s (see 'gs keys list')", query)
+	}
+
+	printKeyEntry(entry, keysShowReveal)
+	return nil
+}
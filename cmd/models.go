@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
-	"github.com/albuquerquesz/gitscribe/internal/auth"
+	"github.com/albuquerquesz/gitscribe/internal/agents"
 	"github.com/albuquerquesz/gitscribe/internal/catalog"
 	appconfig "github.com/albuquerquesz/gitscribe/internal/config"
 	"github.com/albuquerquesz/gitscribe/internal/secrets"
 	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/albuquerquesz/gitscribe/internal/tui"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 )
 
@@ -19,41 +24,115 @@ var modelsCmd = &cobra.Command{
 	},
 }
 
+var modelsRefreshCmd = &cobra.Command{
+	Use:   "refresh [providers...]",
+	Short: "Fetch the latest model lists from provider APIs",
+	Long: `Query each provider's model-listing endpoint with your configured keys
+and cache the result. Providers that cannot be reached keep the cached or
+built-in list.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return refreshModels(args)
+	},
+}
+
+var modelsNoVerify bool
+
 func init() {
+	modelsCmd.Flags().BoolVar(&modelsNoVerify, "no-verify", false, "Skip checking entered API keys with the provider")
+	modelsCmd.AddCommand(modelsRefreshCmd)
 	rootCmd.AddCommand(modelsCmd)
 }
 
 func runModelsInteractive() error {
-	manager := catalog.NewCatalogManager(auth.LoadAPIKey)
+	cfg, err := appconfig.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
 
-	fmt.Println(style.TitleStyle.Render("\n AI Model Catalog"))
+	if err := unlockSecrets(); err != nil {
+		return err
+	}
+
+	manager, err := getCatalogManager()
+	if err != nil {
+		return err
+	}
 
-	selected, err := style.SelectModel(manager)
-	if err != nil || selected == nil {
+	var browser tui.Model
+	_ = style.RunWithSpinner("Loading model catalog...", func() error {
+		keys := newProviderKeyStore()
+		if !modelsNoVerify {
+			keys.factory = agents.NewFactory(cfg)
+		}
+		browser = tui.NewModel(cfg, manager, keys)
 		return nil
+	})
+
+	result, err := tea.NewProgram(browser, tea.WithAltScreen()).Run()
+	if err != nil {
+		return fmt.Errorf("failed to run model browser: %w", err)
 	}
 
-	return handleModelSelection(*selected, manager)
+	selected := result.(tui.Model).GetSelected()
+	if selected == nil {
+		return nil
+	}
+
+	return handleModelSelection(selected.Model, manager)
 }
 
-func handleModelSelection(m catalog.Model, manager *catalog.CatalogManager) error {
-	apiKey, err := validateAuth(m)
+type providerKeyStore struct {
+	keys    *secrets.KeyStore
+	factory *agents.Factory
+}
+
+func newProviderKeyStore() providerKeyStore {
+	return providerKeyStore{keys: secrets.NewKeyStore()}
+}
+
+func (p providerKeyStore) Load(provider string) (string, error) {
+	return p.keys.RetrieveProviderKey(provider)
+}
 
+func (p providerKeyStore) Store(provider, apiKey string) (string, error) {
+	warning := ""
+	if p.factory != nil {
+		err := p.factory.ValidateProviderKey(context.Background(), provider, apiKey)
+		var verr *agents.KeyValidationError
+		switch {
+		case errors.As(err, &verr) && verr.Fatal():
+			return "", verr
+		case err != nil:
+			warning = err.Error()
+		}
+	}
+	return warning, p.keys.StoreProviderKey(provider, apiKey)
+}
+
+func (p providerKeyStore) Delete(provider string) error {
+	return p.keys.DeleteProviderKey(provider)
+}
+
+func handleModelSelection(m catalog.Model, manager *catalog.CatalogManager) error {
 	cfg, err := appconfig.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	profileName := fmt.Sprintf("%s-%s", m.Provider, m.ID)
-	if len(m.ID) > len(m.Provider) && m.ID[:len(m.Provider)] == m.Provider {
-		profileName = m.ID
+	if err := validateAuth(cfg, m); err != nil {
+		return err
 	}
 
-	keyMgr := secrets.NewAgentKeyManager()
-	keyringKey := keyMgr.GetAgentKeyName(profileName)
+	profileName := catalog.ProfileName(m.Provider, m.ID)
+
+	keyringKey := secrets.KeyName(secrets.ScopeProvider, m.Provider)
 
 	pConfig, _ := manager.GetProviderConfig(m.Provider)
 
+	if !pConfig.RequiresAPIKey() {
+		keyringKey = ""
+	}
+
 	existing, err := cfg.GetAgentByName(profileName)
 	if err == nil {
 		existing.Enabled = true
@@ -78,8 +157,9 @@ func handleModelSelection(m catalog.Model, manager *catalog.CatalogManager) erro
 		}
 	}
 
-	if err := keyMgr.StoreAgentKey(profileName, apiKey); err != nil {
-		style.Warning(fmt.Sprintf("Failed to link API key to agent: %v", err))
+	agent, _ := cfg.GetAgentByName(profileName)
+	if err := ensureOllamaReady(*agent); err != nil {
+		return err
 	}
 
 	if err := cfg.SetDefaultAgent(profileName); err != nil {
@@ -94,33 +174,98 @@ func handleModelSelection(m catalog.Model, manager *catalog.CatalogManager) erro
 	return nil
 }
 
-func validateAuth(m catalog.Model) (string, error) {
-	apiKey, err := auth.LoadAPIKey(m.Provider)
-	isAuthenticated := err == nil && apiKey != ""
+func validateAuth(cfg *appconfig.Config, m catalog.Model) error {
+	if !catalog.RequiresAPIKey(m.Provider) {
+		return nil
+	}
 
-	if isAuthenticated {
-		style.Success(fmt.Sprintf("API key already configured for %s.", m.Provider))
-		return "", nil
+	if apiKey, source := secrets.NewKeyStore().ResolveProviderKey(m.Provider); apiKey != "" {
+		style.Success(fmt.Sprintf("API key already configured for %s (%s).", m.Provider, source))
+		return nil
 	}
 	style.Info(fmt.Sprintf("Model %s from %s requires an API key.", m.Name, m.Provider))
 
 	apiKeyInput, err := style.Prompt(fmt.Sprintf("Enter API key for %s", m.Provider))
 	if err != nil {
-		return "", fmt.Errorf("failed to get API key: %w", err)
+		return fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	if !modelsNoVerify {
+		pConfig, _ := catalog.GetProviderConfig(m.Provider)
+		profile := appconfig.AgentProfile{
+			Name:     catalog.ProfileName(m.Provider, m.ID),
+			Provider: appconfig.AgentProvider(m.Provider),
+			Model:    m.ID,
+			BaseURL:  pConfig.BaseURL,
+		}
+		if err := verifyAPIKey(cfg, profile, apiKeyInput); err != nil {
+			return err
+		}
 	}
 
-	if err := auth.StoreAPIKey(m.Provider, apiKeyInput); err != nil {
-		return "", fmt.Errorf("failed to store API key: %w", err)
+	keys := newProviderKeyStore()
+	if _, err := keys.Store(m.Provider, apiKeyInput); err != nil {
+		return fmt.Errorf("failed to store API key: %w", err)
 	}
 
-	apiKey, err = auth.LoadAPIKey(m.Provider)
-	if err != nil || apiKey == "" {
-		return "", fmt.Errorf("API key was not stored correctly")
+	if apiKey, err := keys.Load(m.Provider); err != nil || apiKey == "" {
+		return fmt.Errorf("API key was not stored correctly")
 	}
 
-	return apiKey, nil
+	return nil
+}
+
+func refreshModels(providers []string) error {
+	if err := unlockSecrets(); err != nil {
+		return err
+	}
+
+	manager, err := getCatalogManager()
+	if err != nil {
+		return err
+	}
+
+	if len(providers) == 0 {
+		providers = manager.ListProviders()
+	}
+
+	var results map[string]error
+	err = style.RunWithSpinner("Refreshing model catalog...", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+		results = manager.Refresh(ctx, providers)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	refreshed := 0
+	for _, p := range providers {
+		if err := results[p]; err != nil {
+			style.Warning(fmt.Sprintf("%s: %v (using cached or built-in list)", p, err))
+			continue
+		}
+		refreshed++
+		style.Success(fmt.Sprintf("%s: %d models", p, len(manager.GetModelsByProvider(p))))
+	}
+
+	if refreshed == 0 {
+		style.Info("No provider could be reached. The built-in model list will be used.")
+	}
+	return nil
 }
 
 func getCatalogManager() (*catalog.CatalogManager, error) {
-	return catalog.NewCatalogManager(auth.LoadAPIKey), nil
+	cfg, err := appconfig.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := catalog.Configure(cfg); err != nil {
+		return nil, err
+	}
+
+	keys := newProviderKeyStore()
+	lister := agents.NewModelLister(cfg, keys.Load)
+	return catalog.NewCatalogManager(keys.Load).WithLister(lister), nil
 }
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/ollama"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+)
+
+func ensureOllamaReady(profile config.AgentProfile) error {
+	if profile.Provider != config.ProviderOllama {
+		return nil
+	}
+
+	ctx := context.Background()
+	client := ollama.NewClient(ollama.HostFromBaseURL(profile.BaseURL))
+
+	if err := client.Health(ctx); err != nil {
+		style.Error(fmt.Sprintf("Ollama is not running at %s", client.Host()))
+		style.Info("Start it with 'ollama serve' or install it from https://ollama.com")
+		return err
+	}
+
+	found, err := client.HasModel(ctx, profile.Model)
+	if err != nil {
+		return err
+	}
+	if found {
+		return nil
+	}
+
+	style.Warning(fmt.Sprintf("Model '%s' is not installed in Ollama.", profile.Model))
+	if !style.ConfirmAction(fmt.Sprintf("Pull '%s' now?", profile.Model)) {
+		return fmt.Errorf("ollama model not installed: %s", profile.Model)
+	}
+
+	var last string
+	err = style.RunWithSpinner(fmt.Sprintf("Pulling %s...", profile.Model), func() error {
+		return client.Pull(ctx, profile.Model, func(status string) {
+			last = status
+		})
+	})
+	if err != nil {
+		style.Error(fmt.Sprintf("Failed to pull model: %v", err))
+		return err
+	}
+	style.Success(fmt.Sprintf("Model %s pulled (%s)", profile.Model, last))
+	return nil
+}
+
+func resolveCommitAgent(cfg *config.Config, override string) (*config.AgentProfile, error) {
+	if override != "" {
+		return cfg.GetAgentByName(override)
+	}
+	return cfg.GetDefaultAgent()
+}
@@ -1,23 +1,22 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 
-	"github.com/albuquerquesz/gitscribe/internal/agents"
+	"github.com/albuquerquesz/gitscribe/internal/ai"
 	"github.com/albuquerquesz/gitscribe/internal/config"
 	"github.com/albuquerquesz/gitscribe/internal/git"
-	"github.com/albuquerquesz/gitscribe/internal/router"
 	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/albuquerquesz/gitscribe/internal/tui"
 	"github.com/spf13/cobra"
 )
 
 var (
 	prTitle, prBody, prTarget string
-	prDraft                   bool
+	prDraft, prNoCache        bool
 )
 
 var prCmd = &cobra.Command{
@@ -33,6 +32,7 @@ func init() {
 	prCmd.Flags().StringVarP(&prBody, "body", "b", "", "Pull request body")
 	prCmd.Flags().StringVar(&prTarget, "target", "", "Target branch (default: main/master)")
 	prCmd.Flags().BoolVar(&prDraft, "draft", false, "Create as draft PR")
+	prCmd.Flags().BoolVar(&prNoCache, "no-cache", false, "Always send the request instead of reusing a cached response")
 
 	rootCmd.AddCommand(prCmd)
 }
@@ -208,10 +208,26 @@ func generatePR(provider string) error {
 		style.Warning("No commits found to generate PR description")
 		return nil
 	}
+
+	ai.SetCacheEnabled(!prNoCache)
+	conv, err := ai.NewPRConversation(getProjectPath(), commits, provider, "")
+	if err != nil {
+		style.Error(fmt.Sprintf("Failed to generate PR content: %v", err))
+		return err
+	}
+
+	if cfg, err := config.Load(); err == nil {
+		warnIfOverBudget(cfg, conv.Agent)
+	}
+
+	if err := unlockSecrets(); err != nil {
+		return err
+	}
+
 	var generatedContent string
 	err = style.RunWithSpinner("Generating PR description...", func() error {
 		var err error
-		generatedContent, err = generatePRContent(commits, provider)
+		generatedContent, err = conv.Send()
 		return err
 	})
 	if err != nil {
@@ -220,9 +236,24 @@ func generatePR(provider string) error {
 	}
 
 	style.Success("PR content generated!")
-
-	action, finalContent := style.ShowCommitPrompt(generatedContent)
-	if action == "cancel" {
+	if conv.FromCache {
+		style.Info("Reused a cached response, no request was made. Pass --no-cache to request a new one.")
+	}
+
+	action, finalContent, err := tui.RunComposer(tui.ComposerOptions{
+		Title:     "Pull request",
+		Message:   generatedContent,
+		Diff:      commits,
+		DiffTitle: "Commits",
+		Agent:     conv.Agent,
+		Cached:    conv.FromCache,
+		Refine:    conv.Refine,
+	})
+	if err != nil {
+		style.Error(fmt.Sprintf("Failed to open PR composer: %v", err))
+		return err
+	}
+	if action == tui.ComposerCancel {
 		style.Warning("PR creation cancelled")
 		return nil
 	}
@@ -257,44 +288,3 @@ func detectDefaultBranch() string {
 	}
 	return "main"
 }
-
-func generatePRContent(commits, provider string) (string, error) {
-	cfg, err := config.Load()
-	if err != nil {
-		return "", fmt.Errorf("failed to load config: %w", err)
-	}
-
-	agent, err := cfg.GetDefaultAgent()
-	if err != nil {
-		return "", fmt.Errorf("no suitable agent found: %w", err)
-	}
-
-	r := router.NewRouter(cfg)
-
-	prompt := fmt.Sprintf(
-		"Generate a pull request title and body based on the following git commits. "+
-			"The response should have the title on the first line, followed by a blank line, then the body. "+
-			"The body should describe what changes were made and why. "+
-			"For %s, use markdown formatting in the body. "+
-			"Here are the commits:\n\n%s",
-		provider, commits,
-	)
-
-	messages := []agents.Message{
-		{
-			Role:    "user",
-			Content: prompt,
-		},
-	}
-
-	options := agents.RequestOptions{
-		Temperature: 0.7,
-	}
-
-	resp, err := r.RouteRequest(context.Background(), agent.Name, messages, options)
-	if err != nil {
-		return "", fmt.Errorf("ai request failed: %w", err)
-	}
-
-	return resp.Content, nil
-}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage configuration profiles",
+	Long: `Keep separate sets of agents, routing rules and API keys, for example for work
+and personal repositories. Profiles can be selected automatically by repository
+path or remote URL, switched manually, or chosen per command with --profile.`,
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+}
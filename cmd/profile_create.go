@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profilePaths   []string
+	profileRemotes []string
+	profileCopy    bool
+	profileSwitch  bool
+	profileGlobal  bool
+)
+
+var profileCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create a profile",
+	Long: `Create a profile with its own config file and keyring namespace.
+
+Examples:
+  gs profile create work --path "~/work/**" --remote "github.com/acme/*"
+  gs profile create personal --copy --switch`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return createProfile(args[0])
+	},
+}
+
+func init() {
+	profileCreateCmd.Flags().StringArrayVar(&profilePaths, "path", nil, "Use this profile for repositories under a path glob (repeatable)")
+	profileCreateCmd.Flags().StringArrayVar(&profileRemotes, "remote", nil, "Use this profile for remotes matching a pattern such as github.com/acme/* (repeatable)")
+	profileCreateCmd.Flags().BoolVar(&profileCopy, "copy", false, "Start from the current profile's agents and settings (API keys are not copied)")
+	profileCreateCmd.Flags().BoolVar(&profileSwitch, "switch", false, "Switch to the new profile")
+	profileCreateCmd.Flags().BoolVar(&profileGlobal, "global-keys", false, "Fall back to the global provider env vars and OpenCode login when the profile has no key")
+
+	profileCmd.AddCommand(profileCreateCmd)
+}
+
+func createProfile(name string) error {
+	if err := config.ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	index, err := config.LoadProfiles()
+	if err != nil {
+		return err
+	}
+	if index.Exists(name) {
+		return fmt.Errorf("profile already exists: %s", name)
+	}
+
+	cfg := config.DefaultConfig()
+	if profileCopy {
+		if cfg, err = config.Load(); err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+	}
+
+	path, err := config.ProfilePath(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("config file already exists: %s", path)
+	}
+
+	index.Profiles = append(index.Profiles, config.Profile{Name: name, Paths: profilePaths, Remotes: profileRemotes, GlobalKeys: profileGlobal})
+	if profileSwitch {
+		index.Active = name
+	}
+	if err := index.Save(); err != nil {
+		return err
+	}
+
+	config.SetProfileOverride(name)
+	defer config.SetProfileOverride(profileName)
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	style.Success(fmt.Sprintf("Profile %s created (%s)", name, path))
+	if profileSwitch {
+		style.Info(fmt.Sprintf("Switched to %s", name))
+	}
+	if profileCopy {
+		style.Info(fmt.Sprintf("API keys are per profile. Run 'gs --profile %s agent set-key <agent>' for each agent.", name))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var profileDeleteForce bool
+
+var profileDeleteCmd = &cobra.Command{
+	Use:          "delete [name]",
+	Aliases:      []string{"rm"},
+	Short:        "Delete a profile, its config file and its API keys",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return deleteProfile(args[0])
+	},
+}
+
+func init() {
+	profileDeleteCmd.Flags().BoolVarP(&profileDeleteForce, "force", "f", false, "Skip confirmation")
+
+	profileCmd.AddCommand(profileDeleteCmd)
+}
+
+func deleteProfile(name string) error {
+	if name == config.DefaultProfile {
+		return fmt.Errorf("the default profile cannot be deleted")
+	}
+
+	index, err := config.LoadProfiles()
+	if err != nil {
+		return err
+	}
+	if !index.Exists(name) {
+		return fmt.Errorf("profile not found: %s", name)
+	}
+
+	if !profileDeleteForce && !style.ConfirmAction(fmt.Sprintf("Delete profile %s and its API keys?", name)) {
+		style.Info("Deletion cancelled")
+		return nil
+	}
+
+	path, err := config.ProfilePath(name)
+	if err != nil {
+		return err
+	}
+
+	config.SetProfileOverride(name)
+	cfg, err := config.Load()
+	config.SetProfileOverride(profileName)
+	if err == nil {
+		keys := secrets.NewProfileKeyStore(name)
+		for _, agent := range cfg.Agents {
+			_ = keys.DeleteAgentKey(agent.Name)
+		}
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete %s: %w", path, err)
+	}
+
+	index.Remove(name)
+	if err := index.Save(); err != nil {
+		return err
+	}
+
+	style.Success(fmt.Sprintf("Profile %s deleted", name))
+	return nil
+}
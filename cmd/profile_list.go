@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles and their selection rules",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listProfiles()
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+}
+
+func listProfiles() error {
+	index, err := config.LoadProfiles()
+	if err != nil {
+		return err
+	}
+	active, reason := config.ResolveProfile()
+
+	fmt.Println("👤 Profiles")
+	fmt.Println(strings.Repeat("─", 50))
+
+	for _, name := range index.Names() {
+		marker := "  "
+		if name == active {
+			marker = "▸ "
+		}
+		line := marker + name
+		if name == index.Active {
+			line += " (switched)"
+		}
+		fmt.Println(line)
+
+		if p, ok := index.Get(name); ok {
+			for _, path := range p.Paths {
+				fmt.Printf("    path:   %s\n", path)
+			}
+			for _, remote := range p.Remotes {
+				fmt.Printf("    remote: %s\n", remote)
+			}
+			if p.GlobalKeys {
+				fmt.Println("    keys:   falls back to global env vars and OpenCode")
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Active here: %s (%s)\n", active, reason)
+	return nil
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var profileSwitchCmd = &cobra.Command{
+	Use:          "switch [name]",
+	Short:        "Switch the profile used when no rule matches",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return switchProfile(args[0])
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileSwitchCmd)
+}
+
+func switchProfile(name string) error {
+	index, err := config.LoadProfiles()
+	if err != nil {
+		return err
+	}
+	if !index.Exists(name) {
+		return fmt.Errorf("profile not found: %s", name)
+	}
+
+	index.Active = name
+	if name == config.DefaultProfile {
+		index.Active = ""
+	}
+	if err := index.Save(); err != nil {
+		return err
+	}
+
+	style.Success(fmt.Sprintf("Switched to profile %s", name))
+
+	config.SetProfileOverride("")
+	if active, reason := config.ResolveProfile(); active != name {
+		style.Warning(fmt.Sprintf("This repository still uses %s (%s)", active, reason))
+	}
+	return nil
+}
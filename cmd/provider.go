@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var providerCmd = &cobra.Command{
+	Use:   "provider",
+	Short: "Manage custom OpenAI-compatible providers",
+	Long:  "Register, list, and remove user-defined providers such as LM Studio, vLLM, LiteLLM proxies or internal gateways",
+}
+
+func init() {
+	rootCmd.AddCommand(providerCmd)
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	newProviderName       string
+	newProviderBaseURL    string
+	newProviderAuthMethod string
+	newProviderAuthHeader string
+	newProviderEnvVar     string
+	newProviderHeaders    []string
+	newProviderModels     []string
+)
+
+var providerAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Register a custom OpenAI-compatible provider",
+	Example: `  gs provider add -n lmstudio -u http://localhost:1234/v1 --auth none -m qwen2.5-7b-instruct
+  gs provider add -n litellm -u https://llm.internal/v1 --env LITELLM_KEY -m gpt-4o -m claude-sonnet
+  gs provider add -n gateway -u https://gw.corp/v1 --auth header --auth-header X-Api-Key -H "X-Team=platform"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return addProvider()
+	},
+}
+
+func init() {
+	providerAddCmd.Flags().StringVarP(&newProviderName, "name", "n", "", "Provider name (required)")
+	providerAddCmd.Flags().StringVarP(&newProviderBaseURL, "base-url", "u", "", "OpenAI-compatible base URL (required)")
+	providerAddCmd.Flags().StringVar(&newProviderAuthMethod, "auth", "bearer", "Auth method: bearer, header, azure, sigv4, none")
+	providerAddCmd.Flags().StringVar(&newProviderAuthHeader, "auth-header", "", "Header carrying the API key when --auth header (default: api-key)")
+	providerAddCmd.Flags().StringVar(&newProviderEnvVar, "env", "", "Environment variable holding the API key")
+	providerAddCmd.Flags().StringArrayVarP(&newProviderHeaders, "header", "H", nil, "Extra header sent with every request (Key=Value)")
+	providerAddCmd.Flags().StringArrayVarP(&newProviderModels, "model", "m", nil, "Model offered by the provider (repeatable)")
+	providerAddCmd.MarkFlagRequired("name")
+	providerAddCmd.MarkFlagRequired("base-url")
+
+	providerCmd.AddCommand(providerAddCmd)
+}
+
+func addProvider() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if _, err := catalog.ParseAuthMethod(newProviderAuthMethod); err != nil {
+		return err
+	}
+
+	headers, err := parseHeaderFlags(newProviderHeaders)
+	if err != nil {
+		return err
+	}
+
+	provider := config.ProviderDefinition{
+		Name:       newProviderName,
+		BaseURL:    strings.TrimSuffix(newProviderBaseURL, "/"),
+		AuthMethod: newProviderAuthMethod,
+		AuthHeader: newProviderAuthHeader,
+		Headers:    headers,
+		EnvVar:     newProviderEnvVar,
+		Models:     newProviderModels,
+	}
+
+	if err := cfg.AddProvider(provider); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("Provider '%s' added successfully!\n", newProviderName)
+	fmt.Printf("Create an agent with: gs agent add -n my-%s -p %s -m <model>\n", newProviderName, newProviderName)
+	return nil
+}
+
+func parseHeaderFlags(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	headers := make(map[string]string, len(values))
+	for _, h := range values {
+		k, v, ok := strings.Cut(h, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("invalid header %q (expected Key=Value)", h)
+		}
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return headers, nil
+}
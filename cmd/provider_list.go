@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var providerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List custom providers",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listProviders()
+	},
+}
+
+func init() {
+	providerCmd.AddCommand(providerListCmd)
+}
+
+func listProviders() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	fmt.Println("🔌 Custom Providers")
+	fmt.Println(strings.Repeat("─", 50))
+
+	if len(cfg.Providers) == 0 {
+		fmt.Println("No custom providers configured. Use 'gs provider add' to register one.")
+		return nil
+	}
+
+	for _, p := range cfg.Providers {
+		authMethod := p.AuthMethod
+		if authMethod == "" {
+			authMethod = "bearer"
+		}
+
+		fmt.Printf("• %s\n", p.Name)
+		fmt.Printf("   Base URL: %s\n", p.BaseURL)
+		fmt.Printf("   Auth: %s\n", authMethod)
+		if p.AuthHeader != "" {
+			fmt.Printf("   Auth Header: %s\n", p.AuthHeader)
+		}
+		if p.EnvVar != "" {
+			fmt.Printf("   Env Var: %s\n", p.EnvVar)
+		}
+		if len(p.Headers) > 0 {
+			names := make([]string, 0, len(p.Headers))
+			for k := range p.Headers {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			fmt.Printf("   Headers: %s\n", strings.Join(names, ", "))
+		}
+		if len(p.Models) > 0 {
+			fmt.Printf("   Models: %s\n", strings.Join(p.Models, ", "))
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var providerRemoveCmd = &cobra.Command{
+	Use:     "remove [name]",
+	Aliases: []string{"rm"},
+	Short:   "Remove a custom provider",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return removeProvider(args[0])
+	},
+}
+
+func init() {
+	providerCmd.AddCommand(providerRemoveCmd)
+}
+
+func removeProvider(name string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !style.ConfirmAction(fmt.Sprintf("Remove provider '%s'?", name)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	if err := cfg.RemoveProvider(name); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✅ Provider '%s' removed successfully!\n", name)
+	return nil
+}
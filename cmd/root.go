@@ -1,27 +1,131 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/git"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/albuquerquesz/gitscribe/internal/usage"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var v string = "v1.0.0"
 
+var verbose, debug, traceHTTP bool
+var profileName string
+
 var rootCmd = &cobra.Command{
 	Use:     "gs",
 	Version: v,
 	Short:   "GitScribe: AI-powered commit messages",
 	Long: `GitScribe (gs) helps you generate meaningful commit messages
 using AI (Groq/Llama) and manages your workflow from staging to pushing.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		projectPath := getProjectPath()
+		if err := selectProfile(cmd, projectPath); err != nil {
+			return err
+		}
+		setupLogging()
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			secrets.SetPassphrasePrompt(style.Prompt)
+		}
+		migrateLegacyKeys()
+		command := strings.TrimPrefix(cmd.CommandPath(), "gs ")
+		profile, reason := config.ResolveProfile()
+		logging.Debug("running command", "command", command, "args", strings.Join(args, " "), "profile", profile, "profile_reason", reason)
+		usage.SetContext(command, projectPath)
+		return nil
+	},
 }
 
 func init() {
 	rootCmd.SetVersionTemplate("GitScribe {{.Version}}\n")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Print log output to stderr")
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug logging")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Configuration profile to use (overrides automatic selection)")
+	rootCmd.PersistentFlags().BoolVar(&traceHTTP, "trace-http", false, "Log sanitized HTTP request and response bodies")
+}
+
+func selectProfile(cmd *cobra.Command, projectPath string) error {
+	remote := ""
+	if projectPath != "" {
+		remote, _ = git.GetRemoteURL()
+	}
+	config.SetProfileOverride(profileName)
+	config.SetProfileContext(projectPath, remote)
+
+	profile, reason := config.ResolveProfile()
+	index, err := config.LoadProfiles()
+	if err != nil {
+		return err
+	}
+	if index.Exists(profile) {
+		return nil
+	}
+	if profileName != "" || (cmd != profileCmd && cmd.Parent() != profileCmd) {
+		return fmt.Errorf("profile %q does not exist (from %s), create it with 'gs profile create %s'", profile, reason, profile)
+	}
+	style.Warning(fmt.Sprintf("Profile %q does not exist (from %s), using the default profile for this command.", profile, reason))
+	config.UseDefaultProfile(fmt.Sprintf("fallback, %s named missing profile %q", reason, profile))
+	return nil
+}
+
+func setupLogging() {
+	level := ""
+	if cfg, err := config.Load(); err == nil {
+		level = cfg.Global.LogLevel
+	}
+	_ = logging.Setup(logging.Options{
+		Level:     level,
+		Verbose:   verbose,
+		Debug:     debug,
+		TraceHTTP: traceHTTP,
+	})
+}
+
+func unlockSecrets() error {
+	backend, _ := secrets.ActiveBackend()
+	file, ok := backend.(*secrets.FileBackend)
+	if !ok || !file.Exists() {
+		return nil
+	}
+	if err := file.Unlock(); err != nil {
+		return fmt.Errorf("failed to unlock secrets: %w", err)
+	}
+	return nil
+}
+
+func migrateLegacyKeys() {
+	profile := config.ActiveProfile()
+	if secrets.LegacyKeysMigrated(profile) {
+		return
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	moved, err := secrets.MigrateLegacyKeys(profile, cfg)
+	if err != nil {
+		logging.Debug("legacy key migration skipped", "error", err)
+		return
+	}
+	if moved > 0 {
+		style.Info(fmt.Sprintf("Moved %d stored API key(s) into the unified key store.", moved))
+	}
 }
 
 func Exec() {
 	err := rootCmd.Execute()
+	if err != nil {
+		logging.Error("command failed", "error", err)
+	}
+	logging.Close()
 	if err != nil {
 		os.Exit(1)
 	}
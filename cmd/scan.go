@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/git"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"golang.org/x/term"
+)
+
+func guardDiffSecrets(cfg *config.Config, diff, projectPath string) (string, error) {
+	mode := cfg.Global.SecretScan
+	if mode == "" {
+		mode = config.SecretScanRedact
+	}
+	if mode == config.SecretScanOff || diff == "" {
+		return diff, nil
+	}
+
+	allow, err := git.LoadAllowlist(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	findings := git.ScanDiff(diff, allow)
+	if len(findings) == 0 {
+		return diff, nil
+	}
+	reportSecretFindings(findings)
+
+	added := git.AddedFindings(findings)
+	if mode == config.SecretScanStrict && len(added) > 0 {
+		return "", fmt.Errorf("staged changes contain %d possible secret(s); remove them, allow them in %s or set global.secret_scan to %s", len(added), git.AllowlistFileName, config.SecretScanRedact)
+	}
+
+	if len(added) > 0 && term.IsTerminal(int(os.Stdin.Fd())) {
+		files := git.FindingFiles(added)
+		if style.ConfirmAction(fmt.Sprintf("Unstage %s?", strings.Join(files, ", "))) {
+			if err := git.UnstageFiles(files); err != nil {
+				return "", err
+			}
+			style.Success(fmt.Sprintf("Unstaged %s", strings.Join(files, ", ")))
+
+			diff, err = git.GetStagedDiff()
+			if err != nil {
+				return "", err
+			}
+			findings = git.ScanDiff(diff, allow)
+			if len(findings) == 0 {
+				return diff, nil
+			}
+		}
+	}
+
+	style.Warning(fmt.Sprintf("%d possible secret(s) replaced with placeholders before sending the diff to the AI provider.", len(findings)))
+	return git.RedactSecrets(diff, findings), nil
+}
+
+func redactDiffSecrets(cfg *config.Config, diff, projectPath string) (string, error) {
+	mode := cfg.Global.SecretScan
+	if mode == config.SecretScanOff || diff == "" {
+		return diff, nil
+	}
+
+	allow, err := git.LoadAllowlist(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	findings := git.ScanDiff(diff, allow)
+	if mode == config.SecretScanStrict && len(git.AddedFindings(findings)) > 0 {
+		return "", fmt.Errorf("staged changes contain possible secrets; run gs commit without -m to review them")
+	}
+	return git.RedactSecrets(diff, findings), nil
+}
+
+func reportSecretFindings(findings []git.SecretFinding) {
+	fmt.Println()
+	fmt.Println("🔐 Possible secrets in staged changes")
+	fmt.Println(strings.Repeat("─", 50))
+	for _, f := range findings {
+		note := ""
+		if !f.Added {
+			note = " (already in the repository)"
+		}
+		fmt.Printf("  %s %s:%d  %s  %s%s\n", style.WarningIcon(), f.File, f.Line, f.Rule, style.StringMask(f.Match), note)
+	}
+	fmt.Println()
+	style.Info(fmt.Sprintf("Add a %q comment on the line or an entry in %s to ignore false positives.", git.InlineAllowMarker, git.AllowlistFileName))
+}
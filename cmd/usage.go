@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/albuquerquesz/gitscribe/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	usageDays int
+	usageBy   string
+)
+
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show token usage and estimated cost",
+	Long: `Report the requests recorded in the local usage ledger, grouped by day,
+agent and repository, along with monthly budgets.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showUsage()
+	},
+}
+
+func init() {
+	usageCmd.Flags().IntVarP(&usageDays, "days", "d", 30, "Number of days to include")
+	usageCmd.Flags().StringVar(&usageBy, "by", "", "Only show one grouping (day, agent or repo)")
+
+	rootCmd.AddCommand(usageCmd)
+}
+
+func showUsage() error {
+	groupings := []struct {
+		name  string
+		title string
+		key   func(usage.Entry) string
+	}{
+		{"day", "By day", usage.ByDay},
+		{"agent", "By agent", usage.ByAgent},
+		{"repo", "By repository", usage.ByRepo},
+	}
+
+	if usageBy != "" {
+		found := false
+		for _, g := range groupings {
+			if g.name == usageBy {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("invalid --by value: %s (use day, agent or repo)", usageBy)
+		}
+	}
+
+	since := time.Now().AddDate(0, 0, -usageDays)
+	entries, err := usage.Load(since)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📊 Usage (last %d days)\n", usageDays)
+	fmt.Println(strings.Repeat("─", 50))
+
+	if len(entries) == 0 {
+		fmt.Println("No requests recorded yet.")
+	} else {
+		for _, g := range groupings {
+			if usageBy != "" && g.name != usageBy {
+				continue
+			}
+			fmt.Println(g.title)
+			for _, t := range usage.Summarize(entries, g.key) {
+				printUsageTotal(t)
+			}
+			fmt.Println()
+		}
+
+		total := usage.Summarize(entries, func(usage.Entry) string { return "Total" })
+		printUsageTotal(total[0])
+	}
+
+	return showBudgets()
+}
+
+func printUsageTotal(t usage.Total) {
+	fmt.Printf("   %-28s %5d req  %10s tokens  $%.4f\n", t.Key, t.Requests, formatTokenCount(t.TotalTokens), t.Cost)
+}
+
+func showBudgets() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var lines []string
+	for _, agent := range cfg.Agents {
+		if agent.MonthlyBudget <= 0 {
+			continue
+		}
+		status, err := usage.CheckBudget(agent)
+		if err != nil {
+			return err
+		}
+		marker := "✓"
+		if status.Exceeded() {
+			marker = "✗"
+		}
+		lines = append(lines, fmt.Sprintf("   %s %-26s $%.2f / $%.2f (%.0f%%)",
+			marker, agent.Name, status.Spent, status.Budget, status.Spent/status.Budget*100))
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	action := cfg.Global.BudgetAction
+	if action == "" {
+		action = config.BudgetActionWarn
+	}
+
+	fmt.Println()
+	fmt.Printf("Monthly budgets (%s when exceeded)\n", action)
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func formatTokenCount(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fK", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+func warnIfOverBudget(cfg *config.Config, agentName string) {
+	agent, err := resolveCommitAgent(cfg, agentName)
+	if err != nil {
+		return
+	}
+	status, err := usage.CheckBudget(*agent)
+	if err != nil || !status.Exceeded() {
+		return
+	}
+	msg := fmt.Sprintf("Agent %s has used $%.2f of its $%.2f monthly budget.", agent.Name, status.Spent, status.Budget)
+	if cfg.Global.BudgetAction == config.BudgetActionBlock {
+		style.Error(msg + " Requests are blocked until next month or until the budget is raised.")
+		return
+	}
+	style.Warning(msg)
+}
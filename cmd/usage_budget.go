@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+	"github.com/spf13/cobra"
+)
+
+var budgetAction string
+
+var usageBudgetCmd = &cobra.Command{
+	Use:   "budget [agent] [usd]",
+	Short: "Set a monthly budget for an agent",
+	Long: `Set the monthly spending limit (in USD) for an agent. Use 0 to remove it.
+With --action, choose whether exceeding a budget warns or blocks requests.`,
+	Args: cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setBudget(args)
+	},
+}
+
+func init() {
+	usageBudgetCmd.Flags().StringVar(&budgetAction, "action", "", "What to do when a budget is exceeded (warn or block)")
+
+	usageCmd.AddCommand(usageBudgetCmd)
+}
+
+func setBudget(args []string) error {
+	if len(args) == 1 {
+		return fmt.Errorf("usage: gs usage budget <agent> <usd>")
+	}
+	if len(args) == 0 && budgetAction == "" {
+		return showBudgets()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if budgetAction != "" {
+		if budgetAction != config.BudgetActionWarn && budgetAction != config.BudgetActionBlock {
+			return fmt.Errorf("invalid action: %s (use warn or block)", budgetAction)
+		}
+		cfg.Global.BudgetAction = budgetAction
+	}
+
+	var amount float64
+	if len(args) == 2 {
+		agent, err := cfg.GetAgentByName(args[0])
+		if err != nil {
+			return err
+		}
+		amount, err = strconv.ParseFloat(args[1], 64)
+		if err != nil || amount < 0 {
+			return fmt.Errorf("invalid budget: %s", args[1])
+		}
+		agent.MonthlyBudget = amount
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if len(args) == 2 {
+		if amount == 0 {
+			style.Success(fmt.Sprintf("Monthly budget removed for %s", args[0]))
+		} else {
+			style.Success(fmt.Sprintf("Monthly budget for %s set to $%.2f", args[0], amount))
+		}
+	}
+	switch budgetAction {
+	case config.BudgetActionWarn:
+		style.Success("Exceeded budgets will show a warning")
+	case config.BudgetActionBlock:
+		style.Success("Exceeded budgets will block requests")
+	}
+	return nil
+}
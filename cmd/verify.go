@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/agents"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"github.com/albuquerquesz/gitscribe/internal/style"
+)
+
+func verifyAPIKey(cfg *config.Config, profile config.AgentProfile, apiKey string) error {
+	var err error
+	_ = style.RunWithSpinner(fmt.Sprintf("Verifying API key with %s...", profile.Provider), func() error {
+		err = agents.NewFactory(cfg).ValidateKey(context.Background(), profile, apiKey)
+		return nil
+	})
+	return reportKeyValidation(err)
+}
+
+func verifyKeySource(cfg *config.Config, profile config.AgentProfile) error {
+	apiKey, err := secrets.ReadKeySource(context.Background(), profile.KeySource)
+	if err != nil {
+		return fmt.Errorf("failed to read API key from %s: %w (use --no-verify to save it anyway)", profile.KeySource, err)
+	}
+	return verifyAPIKey(cfg, profile, apiKey)
+}
+
+func reportKeyValidation(err error) error {
+	if err == nil {
+		style.Success("API key verified.")
+		return nil
+	}
+
+	if errors.Is(err, agents.ErrListingUnsupported) {
+		style.Warning(fmt.Sprintf("API key not verified: %v", err))
+		return nil
+	}
+
+	var verr *agents.KeyValidationError
+	if errors.As(err, &verr) {
+		if !verr.Fatal() {
+			style.Warning(verr.Error())
+			return nil
+		}
+		style.Error(verr.Error())
+		return fmt.Errorf("API key verification failed (use --no-verify to save it anyway)")
+	}
+
+	return fmt.Errorf("failed to verify API key: %w", err)
+}
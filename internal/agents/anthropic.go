@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/albuquerquesz/gitscribe/internal/config"
@@ -16,6 +17,8 @@ import (
 const (
 	defaultAnthropicBaseURL = "https://api.anthropic.com/v1"
 	anthropicVersion        = "2023-06-01"
+	anthropicOAuthBeta      = "oauth-2025-04-20"
+	anthropicOAuthPrefix    = "sk-ant-oat"
 )
 
 type AnthropicClient struct {
@@ -25,7 +28,7 @@ type AnthropicClient struct {
 	baseURL string
 }
 
-func NewAnthropicClient(profile config.AgentProfile, apiKey string) (*AnthropicClient, error) {
+func NewAnthropicClient(profile config.AgentProfile, apiKey string, httpClient *http.Client) (*AnthropicClient, error) {
 	if apiKey == "" {
 		return nil, fmt.Errorf("API key is required for agent: %s", profile.Name)
 	}
@@ -35,10 +38,12 @@ func NewAnthropicClient(profile config.AgentProfile, apiKey string) (*AnthropicC
 		baseURL = profile.BaseURL
 	}
 
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
 	return &AnthropicClient{
-		client: &http.Client{
-			Timeout: 60 * time.Second,
-		},
+		client:  httpClient,
 		profile: profile,
 		apiKey:  apiKey,
 		baseURL: baseURL,
@@ -133,7 +138,7 @@ func (c *AnthropicClient) SendMessage(ctx context.Context, messages []Message, o
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("x-api-key", c.apiKey)
+	setAnthropicAuth(req.Header, c.apiKey)
 	req.Header.Set("anthropic-version", anthropicVersion)
 	req.Header.Set("content-type", "application/json")
 
@@ -149,7 +154,7 @@ func (c *AnthropicClient) SendMessage(ctx context.Context, messages []Message, o
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("anthropic api error (%d): %s", resp.StatusCode, string(body))
+		return nil, &APIError{Provider: "anthropic", StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var anthropicResp anthropicResponse
@@ -192,3 +197,12 @@ func (c *AnthropicClient) Close() error {
 	secrets.SecureWipe(&c.apiKey)
 	return nil
 }
+
+func setAnthropicAuth(header http.Header, apiKey string) {
+	if strings.HasPrefix(apiKey, anthropicOAuthPrefix) {
+		header.Set("Authorization", "Bearer "+apiKey)
+		header.Set("anthropic-beta", anthropicOAuthBeta)
+		return
+	}
+	header.Set("x-api-key", apiKey)
+}
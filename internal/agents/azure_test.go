@@ -0,0 +1,80 @@
+package agents
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+func newAzureTestServer(t *testing.T, path, apiVersion string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("path = %q, want %q", r.URL.Path, path)
+		}
+		if got := r.URL.Query().Get("api-version"); got != apiVersion {
+			t.Errorf("api-version = %q, want %q", got, apiVersion)
+		}
+		if got := r.Header.Get("api-key"); got != "azure-key" {
+			t.Errorf("api-key = %q, want azure-key", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization = %q, want none", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":"1","object":"chat.completion","model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"fix: azure"},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`)
+	}))
+}
+
+func TestAzureClientUsesDeploymentURL(t *testing.T) {
+	server := newAzureTestServer(t, "/openai/deployments/gpt4o-prod/chat/completions", "2024-06-01")
+	defer server.Close()
+
+	profile := config.AgentProfile{
+		Name:       "azure-test",
+		Provider:   config.ProviderAzure,
+		Model:      "gpt-4o",
+		BaseURL:    server.URL,
+		Deployment: "gpt4o-prod",
+		APIVersion: "2024-06-01",
+	}
+	client, err := NewOpenAIClient(profile, "azure-key", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.SendMessage(context.Background(), []Message{{Role: "user", Content: "diff"}}, RequestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Content != "fix: azure" {
+		t.Errorf("content = %q, want %q", resp.Content, "fix: azure")
+	}
+}
+
+func TestAzureClientDefaults(t *testing.T) {
+	server := newAzureTestServer(t, "/openai/deployments/gpt-4o/chat/completions", defaultAzureAPIVersion)
+	defer server.Close()
+
+	profile := config.AgentProfile{Name: "azure-test", Provider: config.ProviderAzure, Model: "gpt-4o", BaseURL: server.URL + "/"}
+	client, err := NewOpenAIClient(profile, "azure-key", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.SendMessage(context.Background(), []Message{{Role: "user", Content: "diff"}}, RequestOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAzureClientRequiresBaseURL(t *testing.T) {
+	profile := config.AgentProfile{Name: "azure-test", Provider: config.ProviderAzure, Model: "gpt-4o"}
+	if _, err := NewOpenAIClient(profile, "azure-key", nil); err == nil {
+		t.Fatal("expected an error without a base URL")
+	}
+}
@@ -0,0 +1,187 @@
+package agents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+)
+
+const (
+	bedrockAnthropicVersion = "bedrock-2023-05-31"
+	bedrockService          = "bedrock"
+)
+
+type BedrockClient struct {
+	client      *http.Client
+	profile     config.AgentProfile
+	credentials awsCredentials
+	region      string
+	baseURL     string
+}
+
+func NewBedrockClient(profile config.AgentProfile, apiKey string, httpClient *http.Client) (*BedrockClient, error) {
+	creds := resolveAWSCredentials(apiKey)
+	if !creds.valid() {
+		return nil, fmt.Errorf("AWS credentials are required for agent: %s (set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or store ACCESS_KEY_ID:SECRET_ACCESS_KEY as the agent key)", profile.Name)
+	}
+
+	region := profile.Region
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		return nil, fmt.Errorf("AWS region is required for agent: %s (set region in the profile or AWS_REGION)", profile.Name)
+	}
+
+	baseURL := profile.BaseURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://bedrock-runtime.%s.amazonaws.com", region)
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return &BedrockClient{
+		client:      httpClient,
+		profile:     profile,
+		credentials: creds,
+		region:      region,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+type bedrockRequest struct {
+	AnthropicVersion string             `json:"anthropic_version"`
+	Messages         []anthropicMessage `json:"messages"`
+	MaxTokens        int                `json:"max_tokens"`
+	System           string             `json:"system,omitempty"`
+	Temperature      float32            `json:"temperature,omitempty"`
+}
+
+func (c *BedrockClient) SendMessage(ctx context.Context, messages []Message, options RequestOptions) (*Response, error) {
+	if options.Timeout == 0 {
+		options.Timeout = time.Duration(c.profile.Timeout) * time.Second
+	}
+	if options.Timeout == 0 {
+		options.Timeout = 60 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	var bedrockMessages []anthropicMessage
+	systemPrompt := c.profile.SystemPrompt
+
+	for _, msg := range messages {
+		if msg.Role == "system" {
+			if systemPrompt == "" {
+				systemPrompt = msg.Content
+			}
+			continue
+		}
+		bedrockMessages = append(bedrockMessages, anthropicMessage{
+			Role:    msg.Role,
+			Content: msg.Content,
+		})
+	}
+
+	maxTokens := options.MaxTokens
+	if maxTokens == 0 && c.profile.MaxTokens != 0 {
+		maxTokens = c.profile.MaxTokens
+	}
+	if maxTokens == 0 {
+		maxTokens = 4096
+	}
+
+	reqBody := bedrockRequest{
+		AnthropicVersion: bedrockAnthropicVersion,
+		Messages:         bedrockMessages,
+		MaxTokens:        maxTokens,
+		System:           systemPrompt,
+		Temperature:      options.Temperature,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/model/%s/invoke", c.baseURL, awsURIEncode(c.profile.Model, true))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("accept", "application/json")
+	signSigV4(req, jsonBody, c.credentials, c.region, bedrockService, time.Now())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{Provider: "bedrock", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var bedrockResp anthropicResponse
+	if err := json.Unmarshal(body, &bedrockResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	fullText := ""
+	for _, content := range bedrockResp.Content {
+		if content.Type == "text" {
+			fullText += content.Text
+		}
+	}
+
+	return &Response{
+		Content: fullText,
+		Usage: Usage{
+			PromptTokens:     bedrockResp.Usage.InputTokens,
+			CompletionTokens: bedrockResp.Usage.OutputTokens,
+			TotalTokens:      bedrockResp.Usage.InputTokens + bedrockResp.Usage.OutputTokens,
+		},
+		FinishReason: bedrockResp.StopReason,
+		Model:        c.profile.Model,
+	}, nil
+}
+
+func (c *BedrockClient) GetProvider() config.AgentProvider {
+	return c.profile.Provider
+}
+
+func (c *BedrockClient) GetModel() string {
+	return c.profile.Model
+}
+
+func (c *BedrockClient) IsAvailable() bool {
+	return c.credentials.valid()
+}
+
+func (c *BedrockClient) Close() error {
+	secrets.SecureWipe(&c.credentials.SecretAccessKey)
+	secrets.SecureWipe(&c.credentials.SessionToken)
+	return nil
+}
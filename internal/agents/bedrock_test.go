@@ -0,0 +1,103 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+func TestBedrockClientSignsRequests(t *testing.T) {
+	const model = "anthropic.claude-3-haiku-20240307-v1:0"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/model/anthropic.claude-3-haiku-20240307-v1%3A0/invoke"; got != want {
+			t.Errorf("path = %q, want %q", got, want)
+		}
+		if got := r.Header.Get("X-Amz-Security-Token"); got != "session" {
+			t.Errorf("X-Amz-Security-Token = %q, want session", got)
+		}
+
+		amzDate := r.Header.Get("X-Amz-Date")
+		date, err := time.Parse(sigV4TimeFormat, amzDate)
+		if err != nil {
+			t.Fatalf("invalid X-Amz-Date %q: %v", amzDate, err)
+		}
+		body, _ := io.ReadAll(r.Body)
+
+		prefix := "AWS4-HMAC-SHA256 Credential=AKID/" + date.Format(sigV4DateFormat) + "/eu-west-1/bedrock/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token, Signature="
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, prefix) {
+			t.Fatalf("Authorization = %q, want prefix %q", auth, prefix)
+		}
+
+		signed, _ := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+		signed.Header.Set("content-type", r.Header.Get("Content-Type"))
+		signSigV4(signed, body, awsCredentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET", SessionToken: "session"}, "eu-west-1", bedrockService, date)
+		if got := signed.Header.Get("Authorization"); got != auth {
+			t.Errorf("signature does not match the request received:\n got %q\nwant %q", auth, got)
+		}
+
+		var req bedrockRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("invalid request body: %v", err)
+		}
+		if req.AnthropicVersion != bedrockAnthropicVersion || req.System != "be brief" || len(req.Messages) != 1 {
+			t.Errorf("unexpected request body: %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"content":[{"type":"text","text":"feat: add bedrock"}],"stop_reason":"end_turn","usage":{"input_tokens":12,"output_tokens":4}}`)
+	}))
+	defer server.Close()
+
+	profile := config.AgentProfile{
+		Name:     "bedrock-test",
+		Provider: config.ProviderBedrock,
+		Model:    model,
+		BaseURL:  server.URL,
+		Region:   "eu-west-1",
+	}
+	client, err := NewBedrockClient(profile, "AKID:SECRET:session", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	resp, err := client.SendMessage(context.Background(), []Message{
+		{Role: "system", Content: "be brief"},
+		{Role: "user", Content: "diff"},
+	}, RequestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Content != "feat: add bedrock" || resp.Usage.TotalTokens != 16 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestBedrockClientReportsAPIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `{"message":"The security token included in the request is invalid."}`)
+	}))
+	defer server.Close()
+
+	profile := config.AgentProfile{Name: "bedrock-test", Provider: config.ProviderBedrock, Model: "m", BaseURL: server.URL, Region: "us-east-1"}
+	client, err := NewBedrockClient(profile, "AKID:SECRET", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.SendMessage(context.Background(), []Message{{Role: "user", Content: "diff"}}, RequestOptions{})
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("err = %v, want APIError with status 403", err)
+	}
+}
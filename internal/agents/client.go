@@ -3,15 +3,19 @@ package agents
 import (
 	"context"
 	"fmt"
-	"os"
+	"net/http"
 	"strings"
 	"time"
 
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
 	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
 	"github.com/albuquerquesz/gitscribe/internal/secrets"
 	openai "github.com/sashabaranov/go-openai"
 )
 
+const defaultAzureAPIVersion = "2024-10-21"
+
 type Client interface {
 	SendMessage(ctx context.Context, messages []Message, options RequestOptions) (*Response, error)
 	GetProvider() config.AgentProvider
@@ -30,10 +34,12 @@ type RequestOptions struct {
 	MaxTokens   int
 	Timeout     time.Duration
 	Stream      bool
+	N           int
 }
 
 type Response struct {
 	Content      string
+	Choices      []string
 	Usage        Usage
 	FinishReason string
 	Model        string
@@ -52,14 +58,39 @@ type OpenAIClient struct {
 	provider config.AgentProvider
 }
 
-func NewOpenAIClient(profile config.AgentProfile, apiKey string) (*OpenAIClient, error) {
-	if apiKey == "" {
+func NewOpenAIClient(profile config.AgentProfile, apiKey string, httpClient *http.Client) (*OpenAIClient, error) {
+	pConfig, _ := catalog.GetProviderConfig(string(profile.Provider))
+	if apiKey == "" && catalog.RequiresAPIKey(string(profile.Provider)) {
 		return nil, fmt.Errorf("API key is required for agent: %s", profile.Name)
 	}
 
-	cfg := openai.DefaultConfig(apiKey)
+	headers := make(map[string]string)
+	token := apiKey
+	switch pConfig.AuthMethod {
+	case catalog.AuthMethodNone:
+		token = ""
+	case catalog.AuthMethodHeader:
+		authHeader := pConfig.AuthHeader
+		if authHeader == "" {
+			authHeader = "api-key"
+		}
+		headers[authHeader] = apiKey
+		logging.RegisterSensitiveHeader(authHeader)
+		token = ""
+	}
 
 	baseURL := profile.BaseURL
+	if baseURL == "" && (pConfig.Custom || profile.Provider == config.ProviderGemini) {
+		baseURL = pConfig.BaseURL
+	}
+
+	if pConfig.AuthMethod == catalog.AuthMethodAzure {
+		return newAzureOpenAIClient(profile, apiKey, baseURL, httpClient)
+	}
+
+	cfg := openai.DefaultConfig(token)
+	cfg.HTTPClient = withHeaders(httpClient, headers)
+
 	if baseURL == "" {
 		switch profile.Provider {
 		case config.ProviderGroq:
@@ -88,6 +119,32 @@ func NewOpenAIClient(profile config.AgentProfile, apiKey string) (*OpenAIClient,
 	}, nil
 }
 
+func newAzureOpenAIClient(profile config.AgentProfile, apiKey, baseURL string, httpClient *http.Client) (*OpenAIClient, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("base URL is required for Azure agent: %s (e.g. https://<resource>.openai.azure.com)", profile.Name)
+	}
+
+	cfg := openai.DefaultAzureConfig(apiKey, strings.TrimSuffix(baseURL, "/"))
+	cfg.HTTPClient = withHeaders(httpClient, nil)
+	if profile.APIVersion != "" {
+		cfg.APIVersion = profile.APIVersion
+	} else {
+		cfg.APIVersion = defaultAzureAPIVersion
+	}
+	if profile.Deployment != "" {
+		cfg.AzureModelMapperFunc = func(string) string {
+			return profile.Deployment
+		}
+	}
+
+	return &OpenAIClient{
+		client:   openai.NewClientWithConfig(cfg),
+		profile:  profile,
+		apiKey:   apiKey,
+		provider: profile.Provider,
+	}, nil
+}
+
 func (c *OpenAIClient) SendMessage(ctx context.Context, messages []Message, options RequestOptions) (*Response, error) {
 	if options.Timeout == 0 {
 		options.Timeout = time.Duration(c.profile.Timeout) * time.Second
@@ -135,6 +192,9 @@ func (c *OpenAIClient) SendMessage(ctx context.Context, messages []Message, opti
 		Temperature: temperature,
 		MaxTokens:   maxTokens,
 	}
+	if options.N > 1 {
+		req.N = options.N
+	}
 
 	resp, err := c.client.CreateChatCompletion(ctx, req)
 	if err != nil {
@@ -145,8 +205,14 @@ func (c *OpenAIClient) SendMessage(ctx context.Context, messages []Message, opti
 		return nil, fmt.Errorf("no response choices returned")
 	}
 
+	choices := make([]string, 0, len(resp.Choices))
+	for _, choice := range resp.Choices {
+		choices = append(choices, choice.Message.Content)
+	}
+
 	return &Response{
 		Content: resp.Choices[0].Message.Content,
+		Choices: choices,
 		Usage: Usage{
 			PromptTokens:     resp.Usage.PromptTokens,
 			CompletionTokens: resp.Usage.CompletionTokens,
@@ -166,6 +232,9 @@ func (c *OpenAIClient) GetModel() string {
 }
 
 func (c *OpenAIClient) IsAvailable() bool {
+	if !catalog.RequiresAPIKey(string(c.provider)) {
+		return c.client != nil
+	}
 	return c.client != nil && c.apiKey != ""
 }
 
@@ -175,78 +244,80 @@ func (c *OpenAIClient) Close() error {
 }
 
 type Factory struct {
-	secretsManager *secrets.AgentKeyManager
+	keyStore *secrets.KeyStore
+	config   *config.Config
 }
 
-func NewFactory() *Factory {
+func NewFactory(cfg *config.Config) *Factory {
+	if cfg != nil {
+		catalog.RegisterCustomProviders(cfg.Providers)
+	}
 	return &Factory{
-		secretsManager: secrets.NewAgentKeyManager(),
+		keyStore: secrets.NewKeyStore(),
+		config:   cfg,
 	}
 }
 
 func (f *Factory) CreateClient(profile config.AgentProfile) (Client, error) {
-	apiKey, source := f.resolveAPIKey(profile)
-	if apiKey == "" {
-		return nil, fmt.Errorf("no API key found for agent %s (provider: %s). Configure with 'gs auth set-key -p %s' or set %s environment variable",
-			profile.Name, profile.Provider, profile.Provider, getEnvKeyForProvider(profile.Provider))
+	resolved := f.keyStore.Resolve(profile)
+	if resolved.Err != nil && (profile.KeySource != "" || catalog.RequiresAPIKey(string(profile.Provider))) {
+		return nil, fmt.Errorf("failed to read API key for agent %s from %s: %w", profile.Name, resolved.Ref, resolved.Err)
 	}
-	_ = source
+	apiKey := resolved.Key
+	if apiKey == "" && catalog.RequiresAPIKey(string(profile.Provider)) {
+		return nil, fmt.Errorf("no API key found for agent %s (provider: %s). Configure with 'gs agent set-key %s' or set %s environment variable",
+			profile.Name, profile.Provider, profile.Name, f.keyStore.EnvVar(profile.Provider))
+	}
+	f.keyStore.MarkResolved(resolved)
 
-	switch profile.Provider {
-	case config.ProviderOpenAI, config.ProviderGroq, config.ProviderOpenRouter, config.ProviderOllama, config.ProviderOpenCode, config.ProviderHackClub:
-		return NewOpenAIClient(profile, apiKey)
-	case config.ProviderClaude:
-		return NewAnthropicClient(profile, apiKey)
-	default:
-		return nil, fmt.Errorf("unsupported provider: %s", profile.Provider)
+	client, err := f.CreateClientWithKey(profile, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if refresh := f.tokenRefresher(profile, resolved.Source); refresh != nil {
+		return &refreshingClient{inner: client, token: apiKey, profile: profile, factory: f, refresh: refresh}, nil
 	}
+	return client, nil
 }
 
 func (f *Factory) CreateClientWithKey(profile config.AgentProfile, apiKey string) (Client, error) {
-	switch profile.Provider {
-	case config.ProviderOpenAI, config.ProviderGroq, config.ProviderOpenRouter, config.ProviderOllama, config.ProviderOpenCode, config.ProviderHackClub:
-		return NewOpenAIClient(profile, apiKey)
-	case config.ProviderClaude:
-		return NewAnthropicClient(profile, apiKey)
-	default:
-		return nil, fmt.Errorf("unsupported provider: %s", profile.Provider)
+	httpClient, err := f.httpClient(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP transport for agent %s: %w", profile.Name, err)
 	}
-}
 
-func (f *Factory) resolveAPIKey(profile config.AgentProfile) (string, string) {
-	envKey := getEnvKeyForProvider(profile.Provider)
-	if apiKey := os.Getenv(envKey); apiKey != "" {
-		return apiKey, "env"
+	switch profile.Provider {
+	case config.ProviderOpenAI, config.ProviderGroq, config.ProviderOpenRouter, config.ProviderOllama, config.ProviderOpenCode, config.ProviderHackClub, config.ProviderAzure, config.ProviderGemini:
+		return NewOpenAIClient(profile, apiKey, httpClient)
+	case config.ProviderClaude:
+		return NewAnthropicClient(profile, apiKey, httpClient)
+	case config.ProviderBedrock:
+		return NewBedrockClient(profile, apiKey, httpClient)
 	}
 
-	if apiKey, err := f.secretsManager.RetrieveAgentKey(profile.Name); err == nil {
-		return apiKey, "keyring"
+	if f.isCustomProvider(profile.Provider) {
+		pConfig, _ := catalog.GetProviderConfig(string(profile.Provider))
+		if pConfig.AuthMethod == catalog.AuthMethodSigV4 {
+			return NewBedrockClient(profile, apiKey, httpClient)
+		}
+		return NewOpenAIClient(profile, apiKey, httpClient)
 	}
+	return nil, fmt.Errorf("unsupported provider: %s", profile.Provider)
+}
 
-	if apiKey, err := f.secretsManager.RetrieveAgentKey(string(profile.Provider)); err == nil {
-		return apiKey, "keyring"
+func (f *Factory) httpClient(profile config.AgentProfile) (*http.Client, error) {
+	var global config.GlobalConfig
+	if f.config != nil {
+		global = f.config.Global
 	}
-
-	return "", ""
+	pConfig, _ := catalog.GetProviderConfig(string(profile.Provider))
+	return NewHTTPClient(NewTransportOptions(global, profile, pConfig.Headers))
 }
 
-func getEnvKeyForProvider(provider config.AgentProvider) string {
-	switch provider {
-	case config.ProviderOpenAI:
-		return "OPENAI_API_KEY"
-	case config.ProviderGroq:
-		return "GROQ_API_KEY"
-	case config.ProviderClaude:
-		return "ANTHROPIC_API_KEY"
-	case config.ProviderGemini:
-		return "GEMINI_API_KEY"
-	case config.ProviderOpenRouter:
-		return "OPENROUTER_API_KEY"
-	case config.ProviderOpenCode:
-		return "OPENCODE_API_KEY"
-	case config.ProviderHackClub:
-		return "HACKCLUB_API_KEY"
-	default:
-		return strings.ToUpper(string(provider)) + "_API_KEY"
+func (f *Factory) isCustomProvider(provider config.AgentProvider) bool {
+	if f.config == nil {
+		return false
 	}
+	_, err := f.config.GetProvider(string(provider))
+	return err == nil
 }
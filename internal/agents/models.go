@@ -0,0 +1,147 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+type ModelLister struct {
+	factory     *Factory
+	keyResolver func(provider string) (string, error)
+}
+
+func NewModelLister(cfg *config.Config, keyResolver func(provider string) (string, error)) *ModelLister {
+	return &ModelLister{
+		factory:     NewFactory(cfg),
+		keyResolver: keyResolver,
+	}
+}
+
+func (l *ModelLister) ListModels(ctx context.Context, provider string) ([]string, error) {
+	profile := l.profileFor(provider)
+
+	apiKey, _ := l.factory.keyStore.ResolveAPIKey(profile)
+	if apiKey == "" && l.keyResolver != nil {
+		apiKey, _ = l.keyResolver(provider)
+	}
+	if apiKey == "" && catalog.RequiresAPIKey(provider) {
+		return nil, fmt.Errorf("no API key configured for %s", provider)
+	}
+
+	return l.listModels(ctx, profile, apiKey)
+}
+
+func (l *ModelLister) listModels(ctx context.Context, profile config.AgentProfile, apiKey string) ([]string, error) {
+	provider := string(profile.Provider)
+	httpClient, err := l.factory.httpClient(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	switch profile.Provider {
+	case config.ProviderClaude:
+		ids, err = listAnthropicModels(ctx, httpClient, profile, apiKey)
+	case config.ProviderAzure, config.ProviderBedrock:
+		return nil, fmt.Errorf("%w for %s", ErrListingUnsupported, provider)
+	default:
+		pConfig, _ := catalog.GetProviderConfig(provider)
+		if pConfig.AuthMethod == catalog.AuthMethodSigV4 || pConfig.AuthMethod == catalog.AuthMethodAzure {
+			return nil, fmt.Errorf("%w for %s", ErrListingUnsupported, provider)
+		}
+		ids, err = listOpenAIModels(ctx, httpClient, profile, apiKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (l *ModelLister) profileFor(provider string) config.AgentProfile {
+	if cfg := l.factory.config; cfg != nil {
+		for _, agent := range cfg.Agents {
+			if string(agent.Provider) == provider {
+				return agent
+			}
+		}
+	}
+
+	pConfig, _ := catalog.GetProviderConfig(provider)
+	return config.AgentProfile{
+		Name:     provider,
+		Provider: config.AgentProvider(provider),
+		BaseURL:  pConfig.BaseURL,
+	}
+}
+
+func listOpenAIModels(ctx context.Context, httpClient *http.Client, profile config.AgentProfile, apiKey string) ([]string, error) {
+	client, err := NewOpenAIClient(profile, apiKey, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	list, err := client.client.ListModels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+
+	ids := make([]string, 0, len(list.Models))
+	for _, m := range list.Models {
+		ids = append(ids, m.ID)
+	}
+	return ids, nil
+}
+
+func listAnthropicModels(ctx context.Context, httpClient *http.Client, profile config.AgentProfile, apiKey string) ([]string, error) {
+	baseURL := defaultAnthropicBaseURL
+	if profile.BaseURL != "" {
+		baseURL = profile.BaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimSuffix(baseURL, "/")+"/models?limit=1000", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	setAnthropicAuth(req.Header, apiKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{Provider: "anthropic", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var list struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	ids := make([]string, 0, len(list.Data))
+	for _, m := range list.Data {
+		ids = append(ids, m.ID)
+	}
+	return ids, nil
+}
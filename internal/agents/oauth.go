@@ -0,0 +1,102 @@
+package agents
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+)
+
+type tokenRefresher func(ctx context.Context, staleToken string) (string, error)
+
+type refreshingClient struct {
+	mu      sync.Mutex
+	inner   Client
+	token   string
+	profile config.AgentProfile
+	factory *Factory
+	refresh tokenRefresher
+}
+
+func (f *Factory) tokenRefresher(profile config.AgentProfile, source secrets.Source) tokenRefresher {
+	provider := string(profile.Provider)
+	switch source {
+	case secrets.SourceOAuth:
+		return func(ctx context.Context, staleToken string) (string, error) {
+			return f.keyStore.RefreshOAuthToken(ctx, provider, staleToken)
+		}
+	case secrets.SourceOpenCode:
+		if opencode, _ := secrets.LoadOpenCodeAuth(); opencode.CanRefresh(provider) {
+			return func(ctx context.Context, staleToken string) (string, error) {
+				return secrets.RefreshOpenCodeToken(ctx, provider, staleToken)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *refreshingClient) SendMessage(ctx context.Context, messages []Message, options RequestOptions) (*Response, error) {
+	c.mu.Lock()
+	inner, token := c.inner, c.token
+	c.mu.Unlock()
+
+	resp, err := inner.SendMessage(ctx, messages, options)
+	if err == nil {
+		return resp, nil
+	}
+	if status, _ := errorStatus(err); status != http.StatusUnauthorized {
+		return nil, err
+	}
+
+	fresh, rerr := c.refresh(ctx, token)
+	if rerr != nil {
+		logging.Warn("token refresh after 401 failed", "agent", c.profile.Name, "error", rerr)
+		return nil, err
+	}
+
+	retry, cerr := c.swap(inner, fresh)
+	if cerr != nil {
+		return nil, err
+	}
+	logging.Info("retrying request with refreshed oauth token", "agent", c.profile.Name)
+	return retry.SendMessage(ctx, messages, options)
+}
+
+func (c *refreshingClient) swap(stale Client, token string) (Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.inner != stale {
+		return c.inner, nil
+	}
+	client, err := c.factory.CreateClientWithKey(c.profile, token)
+	if err != nil {
+		return nil, err
+	}
+	c.inner.Close()
+	c.inner = client
+	c.token = token
+	return client, nil
+}
+
+func (c *refreshingClient) GetProvider() config.AgentProvider {
+	return c.profile.Provider
+}
+
+func (c *refreshingClient) GetModel() string {
+	return c.profile.Model
+}
+
+func (c *refreshingClient) IsAvailable() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.IsAvailable()
+}
+
+func (c *refreshingClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.Close()
+}
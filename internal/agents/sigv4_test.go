@@ -0,0 +1,79 @@
+package agents
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+var sigV4TestCredentials = awsCredentials{
+	AccessKeyID:     "AKIDEXAMPLE",
+	SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+}
+
+var sigV4TestTime = time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+func TestSignSigV4TestSuite(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		url       string
+		signature string
+	}{
+		{"get-vanilla", "GET", "https://example.amazonaws.com/", "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"},
+		{"post-vanilla", "POST", "https://example.amazonaws.com/", "5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b"},
+		{"get-vanilla-query-order-key-case", "GET", "https://example.amazonaws.com/?Param2=value2&Param1=value1", "b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			signSigV4(req, nil, sigV4TestCredentials, "us-east-1", "service", sigV4TestTime)
+
+			want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=" + tt.signature
+			if got := req.Header.Get("Authorization"); got != want {
+				t.Errorf("Authorization = %q, want %q", got, want)
+			}
+			if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+				t.Errorf("X-Amz-Date = %q, want 20150830T123600Z", got)
+			}
+		})
+	}
+}
+
+func TestSignSigV4SessionToken(t *testing.T) {
+	req, err := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := sigV4TestCredentials
+	creds.SessionToken = "session-token"
+	signSigV4(req, nil, creds, "us-east-1", "service", sigV4TestTime)
+
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "session-token" {
+		t.Errorf("X-Amz-Security-Token = %q, want session-token", got)
+	}
+	_, signed := canonicalSigV4Request(req, sha256Hex(nil))
+	if signed != "host;x-amz-date;x-amz-security-token" {
+		t.Errorf("signed headers = %q", signed)
+	}
+}
+
+func TestCanonicalSigV4RequestDoubleEncodesPath(t *testing.T) {
+	req, err := http.NewRequest("POST", "https://bedrock-runtime.us-east-1.amazonaws.com/model/"+awsURIEncode("anthropic.claude-3-haiku-20240307-v1:0", true)+"/invoke", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := req.URL.EscapedPath(), "/model/anthropic.claude-3-haiku-20240307-v1%3A0/invoke"; got != want {
+		t.Fatalf("request path = %q, want %q", got, want)
+	}
+
+	canonical, _ := canonicalSigV4Request(req, sha256Hex(nil))
+	want := "POST\n/model/anthropic.claude-3-haiku-20240307-v1%253A0/invoke\n\nhost:bedrock-runtime.us-east-1.amazonaws.com\n\nhost\n" + sha256Hex(nil)
+	if canonical != want {
+		t.Errorf("canonical request = %q, want %q", canonical, want)
+	}
+}
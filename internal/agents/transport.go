@@ -0,0 +1,134 @@
+package agents
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+)
+
+type TransportOptions struct {
+	Headers        map[string]string
+	Proxy          string
+	CACertFile     string
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+func NewTransportOptions(global config.GlobalConfig, profile config.AgentProfile, providerHeaders map[string]string) TransportOptions {
+	headers := make(map[string]string)
+	for k, v := range global.CustomHeaders {
+		headers[k] = v
+	}
+	for k, v := range providerHeaders {
+		headers[k] = v
+	}
+	for k, v := range profile.Headers {
+		headers[k] = v
+	}
+
+	opts := TransportOptions{
+		Headers:        headers,
+		Proxy:          global.Proxy,
+		CACertFile:     global.CACertFile,
+		ClientCertFile: global.ClientCertFile,
+		ClientKeyFile:  global.ClientKeyFile,
+	}
+	if profile.Proxy != "" {
+		opts.Proxy = profile.Proxy
+	}
+	if profile.CACertFile != "" {
+		opts.CACertFile = profile.CACertFile
+	}
+	if profile.ClientCertFile != "" {
+		opts.ClientCertFile = profile.ClientCertFile
+		opts.ClientKeyFile = profile.ClientKeyFile
+	}
+	return opts
+}
+
+func NewHTTPClient(opts TransportOptions) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if opts.Proxy != "" {
+		proxyURL, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", opts.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if opts.CACertFile != "" || opts.ClientCertFile != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+		if opts.CACertFile != "" {
+			pool, err := x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+			pem, err := os.ReadFile(opts.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in CA bundle: %s", opts.CACertFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		if opts.ClientCertFile != "" {
+			if opts.ClientKeyFile == "" {
+				return nil, fmt.Errorf("client key file is required with client certificate: %s", opts.ClientCertFile)
+			}
+			cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	rt := logging.Transport(transport)
+	if len(opts.Headers) > 0 {
+		rt = &headerTransport{base: rt, headers: opts.Headers}
+	}
+
+	return &http.Client{Transport: rt}, nil
+}
+
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	return t.base.RoundTrip(req)
+}
+
+func withHeaders(client *http.Client, headers map[string]string) *http.Client {
+	if client == nil {
+		client = &http.Client{}
+	}
+	if len(headers) == 0 {
+		return client
+	}
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	wrapped := *client
+	wrapped.Transport = &headerTransport{base: base, headers: headers}
+	return &wrapped
+}
@@ -0,0 +1,168 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const keyValidationTimeout = 20 * time.Second
+
+var ErrListingUnsupported = errors.New("model listing is not supported")
+
+type APIError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s api error (%d): %s", e.Provider, e.StatusCode, e.Body)
+}
+
+type KeyProblem string
+
+const (
+	KeyInvalid       KeyProblem = "invalid_key"
+	KeyNoModelAccess KeyProblem = "model_access"
+	KeyQuota         KeyProblem = "quota"
+	KeyUnreachable   KeyProblem = "unreachable"
+)
+
+type KeyValidationError struct {
+	Problem  KeyProblem
+	Provider string
+	Model    string
+	Err      error
+}
+
+func (e *KeyValidationError) Error() string {
+	switch e.Problem {
+	case KeyInvalid:
+		return fmt.Sprintf("%s rejected the API key: %v", e.Provider, e.Err)
+	case KeyNoModelAccess:
+		return fmt.Sprintf("the API key works but has no access to model %s: %v", e.Model, e.Err)
+	case KeyQuota:
+		return fmt.Sprintf("the API key is valid but %s reports a quota or billing problem: %v", e.Provider, e.Err)
+	default:
+		return fmt.Sprintf("could not reach %s to verify the API key: %v", e.Provider, e.Err)
+	}
+}
+
+func (e *KeyValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *KeyValidationError) Fatal() bool {
+	return e.Problem == KeyInvalid || e.Problem == KeyNoModelAccess
+}
+
+func (f *Factory) ValidateKey(ctx context.Context, profile config.AgentProfile, apiKey string) error {
+	provider := string(profile.Provider)
+	if !catalog.RequiresAPIKey(provider) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, keyValidationTimeout)
+	defer cancel()
+
+	lister := &ModelLister{factory: f}
+	ids, err := lister.listModels(ctx, profile, apiKey)
+	switch {
+	case err == nil:
+		if profile.Model == "" || len(ids) == 0 || slices.Contains(ids, profile.Model) {
+			return nil
+		}
+	case errors.Is(err, ErrListingUnsupported):
+	default:
+		if problem := classifyKeyError(err, false); problem != "" {
+			return &KeyValidationError{Problem: problem, Provider: provider, Model: profile.Model, Err: err}
+		}
+		if profile.Model == "" {
+			return nil
+		}
+	}
+
+	if profile.Model == "" {
+		if errors.Is(err, ErrListingUnsupported) {
+			return fmt.Errorf("%w for %s, the key can only be checked with a model", ErrListingUnsupported, provider)
+		}
+		return nil
+	}
+	return f.probeKey(ctx, profile, apiKey)
+}
+
+func (f *Factory) ValidateProviderKey(ctx context.Context, provider, apiKey string) error {
+	lister := &ModelLister{factory: f}
+	return f.ValidateKey(ctx, lister.profileFor(provider), apiKey)
+}
+
+func (f *Factory) probeKey(ctx context.Context, profile config.AgentProfile, apiKey string) error {
+	client, err := f.CreateClientWithKey(profile, apiKey)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	_, err = client.SendMessage(ctx, []Message{{Role: "user", Content: "ping"}}, RequestOptions{MaxTokens: 1})
+	if err == nil {
+		return nil
+	}
+	if problem := classifyKeyError(err, true); problem != "" {
+		return &KeyValidationError{Problem: problem, Provider: string(profile.Provider), Model: profile.Model, Err: err}
+	}
+	return nil
+}
+
+func classifyKeyError(err error, probing bool) KeyProblem {
+	status, message := errorStatus(err)
+	message = strings.ToLower(message)
+
+	switch {
+	case status == 0:
+		return KeyUnreachable
+	case status == http.StatusPaymentRequired || status == http.StatusTooManyRequests:
+		return KeyQuota
+	case strings.Contains(message, "quota") || strings.Contains(message, "billing") || strings.Contains(message, "credit"):
+		return KeyQuota
+	case status == http.StatusUnauthorized:
+		return KeyInvalid
+	case status == http.StatusForbidden:
+		if probing && strings.Contains(message, "model") {
+			return KeyNoModelAccess
+		}
+		return KeyInvalid
+	case status == http.StatusNotFound && probing:
+		return KeyNoModelAccess
+	case status == http.StatusBadRequest && probing && strings.Contains(message, "model") &&
+		(strings.Contains(message, "not found") || strings.Contains(message, "does not exist") || strings.Contains(message, "invalid model") || strings.Contains(message, "not supported")):
+		return KeyNoModelAccess
+	case status >= 500:
+		return KeyUnreachable
+	}
+	return ""
+}
+
+func errorStatus(err error) (int, string) {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatusCode, fmt.Sprintf("%v %s", apiErr.Code, apiErr.Message)
+	}
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.HTTPStatusCode, reqErr.Error()
+	}
+	var providerErr *APIError
+	if errors.As(err, &providerErr) {
+		return providerErr.StatusCode, providerErr.Body
+	}
+	return 0, err.Error()
+}
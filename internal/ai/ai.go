@@ -1,18 +1,18 @@
 package ai
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/albuquerquesz/gitscribe/internal/agents"
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
 	"github.com/albuquerquesz/gitscribe/internal/config"
-	"github.com/albuquerquesz/gitscribe/internal/router"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
 )
 
-func SendPrompt(diff string, agentOverride string) (string, error) {
+func resolveAgent(agentOverride string) (*config.Config, *config.AgentProfile, error) {
 	cfg, err := config.Load()
 	if err != nil {
-		return "", fmt.Errorf("failed to load config: %w", err)
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	agent, err := cfg.GetDefaultAgent()
@@ -21,10 +21,19 @@ func SendPrompt(diff string, agentOverride string) (string, error) {
 	}
 
 	if err != nil {
-		return "", fmt.Errorf("no suitable agent found: %w", err)
+		return nil, nil, fmt.Errorf("no suitable agent found: %w", err)
 	}
 
-	r := router.NewRouter(cfg)
+	_ = catalog.Configure(cfg)
+	return cfg, agent, nil
+}
+
+func commitMessages(diff string, agent config.AgentProfile) []agents.Message {
+	budget := TokenBudget(agent)
+	diff, truncated := FitDiffToBudget(diff, budget)
+	if truncated {
+		logging.Info("diff truncated to fit context window", "agent", agent.Name, "model", agent.Model, "budget_tokens", budget)
+	}
 
 	prompt := fmt.Sprintf(
 		"Analyze the following git diff and generate a commit message. "+
@@ -36,21 +45,10 @@ func SendPrompt(diff string, agentOverride string) (string, error) {
 		diff,
 	)
 
-	messages := []agents.Message{
+	return []agents.Message{
 		{
 			Role:    "user",
 			Content: prompt,
 		},
 	}
-
-	options := agents.RequestOptions{
-		Temperature: 0.7,
-	}
-
-	resp, err := r.RouteRequest(context.Background(), agent.Name, messages, options)
-	if err != nil {
-		return "", fmt.Errorf("ai request failed: %w", err)
-	}
-
-	return resp.Content, nil
 }
@@ -0,0 +1,76 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+const (
+	charsPerToken        = 4
+	promptOverheadTokens = 512
+	minDiffBudgetTokens  = 1024
+)
+
+func EstimateTokens(s string) int {
+	return (len(s) + charsPerToken - 1) / charsPerToken
+}
+
+func TokenBudget(agent config.AgentProfile) int {
+	model, ok := catalog.LookupModel(string(agent.Provider), agent.Model)
+	if !ok || model.ContextWindow == 0 {
+		return 0
+	}
+
+	reserve := agent.MaxTokens
+	if reserve == 0 || (model.MaxOutput > 0 && reserve > model.MaxOutput) {
+		reserve = model.MaxOutput
+	}
+	if reserve == 0 {
+		reserve = 1024
+	}
+
+	budget := model.ContextWindow - reserve - promptOverheadTokens
+	if budget < minDiffBudgetTokens {
+		budget = minDiffBudgetTokens
+	}
+	return budget
+}
+
+func FitDiffToBudget(diff string, budget int) (string, bool) {
+	if budget <= 0 || EstimateTokens(diff) <= budget {
+		return diff, false
+	}
+
+	sections := strings.Split(diff, "\ndiff --git ")
+	var b strings.Builder
+	b.WriteString(sections[0])
+	used := EstimateTokens(sections[0])
+
+	omitted := 0
+	for _, section := range sections[1:] {
+		full := "\ndiff --git " + section
+		cost := EstimateTokens(full)
+		if used+cost <= budget {
+			b.WriteString(full)
+			used += cost
+			continue
+		}
+
+		header := full
+		if idx := strings.Index(full, "\n@@"); idx != -1 {
+			header = full[:idx]
+		}
+		headerCost := EstimateTokens(header)
+		if used+headerCost <= budget {
+			b.WriteString(header)
+			used += headerCost
+		}
+		omitted++
+	}
+
+	fmt.Fprintf(&b, "\n\n[diff truncated to fit the model context window: %d file(s) shown without hunks]\n", omitted)
+	return b.String(), true
+}
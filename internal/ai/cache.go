@@ -0,0 +1,169 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/agents"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+)
+
+const (
+	responseCacheDirName    = "response-cache"
+	DefaultResponseCacheTTL = 7 * 24 * time.Hour
+	maxResponseCacheEntries = 500
+	maxResponseCacheBytes   = 10 << 20
+)
+
+var cacheEnabled = true
+
+type cachedResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+	Agent     string    `json:"agent"`
+	Model     string    `json:"model"`
+	Content   string    `json:"content"`
+}
+
+func SetCacheEnabled(enabled bool) {
+	cacheEnabled = enabled
+}
+
+func getResponseCacheDir() (string, error) {
+	dir, err := config.EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, responseCacheDirName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create response cache directory: %w", err)
+	}
+	return dir, nil
+}
+
+func responseCacheKey(agent config.AgentProfile, messages []agents.Message) string {
+	data, _ := json.Marshal(messages)
+	return HashInput(strings.Join([]string{agent.Name, string(agent.Provider), agent.Model, agent.SystemPrompt, string(data)}, "\x00"))
+}
+
+func loadCachedResponse(key string) (string, bool) {
+	dir, err := getResponseCacheDir()
+	if err != nil {
+		return "", false
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, key+".json"))
+	if err != nil {
+		return "", false
+	}
+
+	var entry cachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return "", false
+	}
+	if time.Since(entry.CreatedAt) > DefaultResponseCacheTTL || entry.Content == "" {
+		return "", false
+	}
+	return entry.Content, true
+}
+
+func storeCachedResponse(key string, agent config.AgentProfile, content string) error {
+	dir, err := getResponseCacheDir()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cachedResponse{
+		CreatedAt: time.Now(),
+		Agent:     agent.Name,
+		Model:     agent.Model,
+		Content:   content,
+	})
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, key+".json")
+	tmp := path + ".tmp"
+	logging.Debug("storing cached response", "agent", agent.Name, "key", key[:16])
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	return pruneResponseCache(dir)
+}
+
+func pruneResponseCache(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	type cacheFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var files []cacheFile
+	var total int64
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if time.Since(info.ModTime()) > DefaultResponseCacheTTL {
+			os.Remove(path)
+			continue
+		}
+		files = append(files, cacheFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	for len(files) > maxResponseCacheEntries || total > maxResponseCacheBytes {
+		os.Remove(files[0].path)
+		total -= files[0].size
+		files = files[1:]
+	}
+	return nil
+}
+
+func ClearResponseCache() (int, error) {
+	dir, err := getResponseCacheDir()
+	if err != nil {
+		return 0, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response cache: %w", err)
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %w", e.Name(), err)
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,134 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/agents"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+	"github.com/albuquerquesz/gitscribe/internal/router"
+)
+
+type Candidate struct {
+	Agent   string
+	Model   string
+	Message string
+}
+
+func GenerateCandidates(diff string, agentOverride string, n int) ([]Candidate, error) {
+	cfg, primary, err := resolveAgent(agentOverride)
+	if err != nil {
+		return nil, err
+	}
+	if n < 1 {
+		n = 1
+	}
+
+	r := router.NewRouter(cfg)
+	ctx := context.Background()
+	options := agents.RequestOptions{Temperature: 0.7}
+
+	var candidates []Candidate
+	seen := make(map[string]bool)
+	add := func(agent, model, message string) {
+		message = strings.TrimSpace(message)
+		if message == "" || seen[message] || len(candidates) >= n {
+			return
+		}
+		seen[message] = true
+		candidates = append(candidates, Candidate{Agent: agent, Model: model, Message: message})
+	}
+
+	if supportsChoices(primary.Provider) && n > 1 {
+		primaryOptions := options
+		primaryOptions.N = n
+		resp, err := r.RouteRequest(ctx, primary.Name, commitMessages(diff, *primary), primaryOptions)
+		if err != nil {
+			logging.Warn("multiple choice request failed, falling back to single requests", "agent", primary.Name, "error", err)
+		} else {
+			for _, choice := range responseChoices(resp) {
+				add(primary.Name, modelLabel(resp, primary.Model), choice)
+			}
+		}
+	}
+
+	remaining := n - len(candidates)
+	if remaining <= 0 {
+		return candidates, nil
+	}
+
+	pool := []string{primary.Name}
+	models := map[string]string{primary.Name: primary.Model}
+	if len(candidates) > 0 {
+		pool = pool[:0]
+		for _, agent := range cfg.ListEnabledAgents() {
+			if agent.Name != primary.Name {
+				pool = append(pool, agent.Name)
+				models[agent.Name] = agent.Model
+			}
+		}
+		if len(pool) == 0 {
+			pool = append(pool, primary.Name)
+		}
+	}
+
+	requests := make([]router.Request, 0, remaining)
+	for i := range remaining {
+		name := pool[i%len(pool)]
+		profile, err := cfg.GetAgentByName(name)
+		if err != nil {
+			continue
+		}
+		requests = append(requests, router.Request{
+			Agent:    name,
+			Messages: commitMessages(diff, *profile),
+			Options:  options,
+		})
+	}
+
+	results := r.RouteAll(ctx, requests)
+	for _, result := range results {
+		if result.Err != nil {
+			logging.Warn("candidate request failed", "agent", result.Agent, "error", result.Err)
+			continue
+		}
+		add(result.Agent, modelLabel(result.Response, models[result.Agent]), result.Response.Content)
+	}
+
+	if len(candidates) == 0 {
+		if err := firstError(results); err != nil {
+			return nil, fmt.Errorf("ai request failed: %w", err)
+		}
+		return nil, fmt.Errorf("no candidate messages were generated")
+	}
+	return candidates, nil
+}
+
+func supportsChoices(provider config.AgentProvider) bool {
+	return provider == config.ProviderOpenAI || provider == config.ProviderAzure
+}
+
+func firstError(results []router.Result) error {
+	for _, result := range results {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+	return nil
+}
+
+func responseChoices(resp *agents.Response) []string {
+	if len(resp.Choices) > 0 {
+		return resp.Choices
+	}
+	return []string{resp.Content}
+}
+
+func modelLabel(resp *agents.Response, fallback string) string {
+	if resp != nil && resp.Model != "" {
+		return resp.Model
+	}
+	return fallback
+}
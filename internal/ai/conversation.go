@@ -0,0 +1,236 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/agents"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+	"github.com/albuquerquesz/gitscribe/internal/router"
+)
+
+type ConversationKind string
+
+const (
+	ConversationCommit ConversationKind = "commit"
+	ConversationPR     ConversationKind = "pr"
+
+	sessionsDirName = "sessions"
+)
+
+type Conversation struct {
+	Kind      ConversationKind `json:"kind"`
+	Project   string           `json:"project"`
+	Agent     string           `json:"agent"`
+	InputHash string           `json:"input_hash"`
+	Messages  []agents.Message `json:"messages"`
+	Draft     string           `json:"draft,omitempty"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	FromCache bool             `json:"-"`
+}
+
+func NewCommitConversation(project, diff, agentOverride string) (*Conversation, error) {
+	_, agent, err := resolveAgent(agentOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Conversation{
+		Kind:     ConversationCommit,
+		Project:  project,
+		Agent:    agent.Name,
+		Messages: commitMessages(diff, *agent),
+	}, nil
+}
+
+func NewPRConversation(project, commits, provider, agentOverride string) (*Conversation, error) {
+	_, agent, err := resolveAgent(agentOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt := fmt.Sprintf(
+		"Generate a pull request title and body based on the following git commits. "+
+			"The response should have the title on the first line, followed by a blank line, then the body. "+
+			"The body should describe what changes were made and why. "+
+			"For %s, use markdown formatting in the body. "+
+			"Here are the commits:\n\n%s",
+		provider, commits,
+	)
+
+	return &Conversation{
+		Kind:    ConversationPR,
+		Project: project,
+		Agent:   agent.Name,
+		Messages: []agents.Message{
+			{
+				Role:    "user",
+				Content: prompt,
+			},
+		},
+	}, nil
+}
+
+func (c *Conversation) Send() (string, error) {
+	return c.send(cacheEnabled)
+}
+
+func (c *Conversation) SendFresh() (string, error) {
+	return c.send(false)
+}
+
+func (c *Conversation) send(useCache bool) (string, error) {
+	cfg, agent, err := resolveAgent(c.Agent)
+	if err != nil {
+		return "", err
+	}
+
+	key := responseCacheKey(*agent, c.Messages)
+	c.FromCache = false
+	if useCache {
+		if content, ok := loadCachedResponse(key); ok {
+			logging.Debug("response cache hit", "agent", agent.Name, "key", key[:16])
+			c.FromCache = true
+			c.Accept(agent.Name, content)
+			return content, nil
+		}
+	}
+
+	options := agents.RequestOptions{
+		Temperature: 0.7,
+	}
+
+	resp, err := router.NewRouter(cfg).RouteRequest(context.Background(), agent.Name, c.Messages, options)
+	if err != nil {
+		return "", fmt.Errorf("ai request failed: %w", err)
+	}
+
+	if cacheEnabled {
+		_ = storeCachedResponse(key, *agent, resp.Content)
+	}
+
+	c.Accept(agent.Name, resp.Content)
+	return resp.Content, nil
+}
+
+func (c *Conversation) Accept(agent, answer string) {
+	c.Agent = agent
+	c.Messages = append(c.Messages, agents.Message{Role: "assistant", Content: answer})
+	c.Draft = answer
+	c.UpdatedAt = time.Now()
+}
+
+func (c *Conversation) Refine(agent, current, feedback string) (string, error) {
+	if agent != "" {
+		c.Agent = agent
+	}
+
+	content := "Revise your last answer using this feedback: " + feedback
+	if strings.TrimSpace(current) != strings.TrimSpace(c.LastAnswer()) {
+		content = fmt.Sprintf("I edited your last answer to:\n\n%s\n\nRevise this version using this feedback: %s", current, feedback)
+	}
+	content += "\nKeep the same format and reply with *only* the revised text."
+
+	c.Messages = append(c.Messages, agents.Message{Role: "user", Content: content})
+	answer, err := c.Send()
+	if err != nil {
+		c.Messages = c.Messages[:len(c.Messages)-1]
+		return "", err
+	}
+	return answer, nil
+}
+
+func (c *Conversation) LastAnswer() string {
+	for i := len(c.Messages) - 1; i >= 0; i-- {
+		if c.Messages[i].Role == "assistant" {
+			return c.Messages[i].Content
+		}
+	}
+	return ""
+}
+
+func (c *Conversation) Turns() int {
+	turns := 0
+	for _, m := range c.Messages {
+		if m.Role == "user" {
+			turns++
+		}
+	}
+	return turns
+}
+
+func HashInput(input string) string {
+	sum := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(sum[:])
+}
+
+func getSessionPath(kind ConversationKind, project string) (string, error) {
+	dir, err := config.EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, sessionsDirName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create sessions directory: %w", err)
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s-%s.json", kind, HashInput(project)[:16])), nil
+}
+
+func SaveConversation(c *Conversation) error {
+	path, err := getSessionPath(c.Kind, c.Project)
+	if err != nil {
+		return err
+	}
+
+	c.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+func LoadConversation(kind ConversationKind, project string) (*Conversation, error) {
+	path, err := getSessionPath(kind, project)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no saved %s session for %s", kind, project)
+		}
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var c Conversation
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+	return &c, nil
+}
+
+func DeleteConversation(kind ConversationKind, project string) error {
+	path, err := getSessionPath(kind, project)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove session: %w", err)
+	}
+	return nil
+}
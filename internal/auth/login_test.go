@@ -0,0 +1,243 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeProvider struct {
+	endpoint Endpoint
+}
+
+func (p fakeProvider) Name() string {
+	return "fake"
+}
+
+func (p fakeProvider) Endpoint() Endpoint {
+	return p.endpoint
+}
+
+type fakeAuthServer struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu          sync.Mutex
+	challenge   string
+	redirectURI string
+	codes       map[string]bool
+	refreshes   int
+}
+
+func newFakeAuthServer(t *testing.T) *fakeAuthServer {
+	t.Helper()
+	s := &fakeAuthServer{t: t, codes: make(map[string]bool)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	s.server = httptest.NewServer(mux)
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *fakeAuthServer) endpoint(formEncoded bool) Endpoint {
+	return Endpoint{
+		AuthURL:      s.server.URL + "/authorize",
+		TokenURL:     s.server.URL + "/token",
+		ClientID:     "test-client",
+		Scopes:       []string{"openid", "offline"},
+		CallbackPath: "/auth/callback",
+		FormEncoded:  formEncoded,
+		AuthParams:   map[string]string{"prompt": "login"},
+	}
+}
+
+func (s *fakeAuthServer) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	for key, want := range map[string]string{
+		"response_type":         "code",
+		"client_id":             "test-client",
+		"code_challenge_method": "S256",
+		"scope":                 "openid offline",
+		"prompt":                "login",
+	} {
+		if got := q.Get(key); got != want {
+			s.t.Errorf("authorize %s = %q, want %q", key, got, want)
+		}
+	}
+	if q.Get("state") == "" || q.Get("code_challenge") == "" {
+		s.t.Errorf("authorize request is missing state or code_challenge: %s", r.URL.RawQuery)
+	}
+
+	s.mu.Lock()
+	s.challenge = q.Get("code_challenge")
+	s.redirectURI = q.Get("redirect_uri")
+	code := fmt.Sprintf("code-%d", len(s.codes)+1)
+	s.codes[code] = true
+	s.mu.Unlock()
+
+	callback, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		s.t.Errorf("invalid redirect_uri: %v", err)
+		return
+	}
+	params := url.Values{"code": {code}, "state": {q.Get("state")}}
+	callback.RawQuery = params.Encode()
+	http.Redirect(w, r, callback.String(), http.StatusFound)
+}
+
+func (s *fakeAuthServer) token(w http.ResponseWriter, r *http.Request) {
+	params := make(map[string]string)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if err := r.ParseForm(); err != nil {
+			s.t.Errorf("invalid form body: %v", err)
+		}
+		for k := range r.PostForm {
+			params[k] = r.PostForm.Get(k)
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		s.t.Errorf("invalid JSON body: %v", err)
+	}
+	if params["client_id"] != "test-client" {
+		s.t.Errorf("token client_id = %q", params["client_id"])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	switch params["grant_type"] {
+	case "authorization_code":
+		sum := sha256.Sum256([]byte(params["code_verifier"]))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != s.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant","error_description":"PKCE verification failed"}`)
+			return
+		}
+		if !s.codes[params["code"]] || params["redirect_uri"] != s.redirectURI {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant"}`)
+			return
+		}
+		delete(s.codes, params["code"])
+		fmt.Fprint(w, `{"access_token":"access-1","refresh_token":"refresh-1","expires_in":3600,"scope":"openid offline"}`)
+	case "refresh_token":
+		if params["refresh_token"] != "refresh-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant"}`)
+			return
+		}
+		s.refreshes++
+		fmt.Fprintf(w, `{"access_token":"access-%d","expires_in":60}`, s.refreshes+1)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"unsupported_grant_type"}`)
+	}
+}
+
+func visit(t *testing.T, rawURL string) int {
+	t.Helper()
+	resp, err := http.Get(rawURL)
+	if err != nil {
+		t.Errorf("GET %s: %v", rawURL, err)
+		return 0
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestLoginEndToEnd(t *testing.T) {
+	for _, formEncoded := range []bool{false, true} {
+		t.Run(fmt.Sprintf("form=%v", formEncoded), func(t *testing.T) {
+			fake := newFakeAuthServer(t)
+			provider := fakeProvider{endpoint: fake.endpoint(formEncoded)}
+
+			opened := make(chan struct{})
+			token, err := Login(context.Background(), provider, LoginOptions{
+				Timeout: 10 * time.Second,
+				OpenURL: func(authURL string) error {
+					go func() {
+						defer close(opened)
+						parsed, _ := url.Parse(authURL)
+						redirect := parsed.Query().Get("redirect_uri")
+						if !strings.HasPrefix(redirect, "http://localhost:") || !strings.HasSuffix(redirect, "/auth/callback") {
+							t.Errorf("redirect_uri = %q", redirect)
+						}
+
+						forged := redirect + "?" + url.Values{"code": {"stolen"}, "state": {"forged"}}.Encode()
+						if status := visit(t, forged); status != http.StatusBadRequest {
+							t.Errorf("callback with wrong state returned %d, want 400", status)
+						}
+						if status := visit(t, authURL); status != http.StatusOK {
+							t.Errorf("callback returned %d, want 200", status)
+						}
+					}()
+					return nil
+				},
+			})
+			<-opened
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token.AccessToken != "access-1" || token.RefreshToken != "refresh-1" || token.Scope != "openid offline" {
+				t.Fatalf("unexpected token: %+v", token)
+			}
+			if !token.ExpiresWithin(time.Hour+time.Minute) || token.ExpiresWithin(59*time.Minute) {
+				t.Errorf("unexpected expiry: %v", token.ExpiresAt)
+			}
+
+			refreshed, err := Refresh(context.Background(), provider.Endpoint(), token.RefreshToken)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if refreshed.AccessToken != "access-2" {
+				t.Errorf("refreshed access token = %q, want access-2", refreshed.AccessToken)
+			}
+			if refreshed.RefreshToken != "refresh-1" {
+				t.Errorf("refresh token = %q, want the previous one to be kept", refreshed.RefreshToken)
+			}
+
+			if _, err := Refresh(context.Background(), provider.Endpoint(), "revoked"); err == nil {
+				t.Error("expected an error for an invalid refresh token")
+			}
+		})
+	}
+}
+
+func TestLoginAccessDenied(t *testing.T) {
+	fake := newFakeAuthServer(t)
+	_, err := Login(context.Background(), fakeProvider{endpoint: fake.endpoint(false)}, LoginOptions{
+		Timeout: 10 * time.Second,
+		OpenURL: func(authURL string) error {
+			parsed, _ := url.Parse(authURL)
+			q := parsed.Query()
+			denied := q.Get("redirect_uri") + "?" + url.Values{"error": {"access_denied"}, "state": {q.Get("state")}}.Encode()
+			go visit(t, denied)
+			return nil
+		},
+	})
+	if !errors.Is(err, ErrAccessDenied) {
+		t.Fatalf("err = %v, want ErrAccessDenied", err)
+	}
+}
+
+func TestLoginTimeout(t *testing.T) {
+	fake := newFakeAuthServer(t)
+	_, err := Login(context.Background(), fakeProvider{endpoint: fake.endpoint(false)}, LoginOptions{
+		Timeout: 200 * time.Millisecond,
+		OpenURL: func(string) error { return nil },
+	})
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("err = %v, want ErrTimeout", err)
+	}
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+)
+
+const (
+	DefaultLoginTimeout = 5 * time.Minute
+	defaultCallbackPath = "/callback"
+)
+
+const callbackPage = `<!doctype html>
+<html><head><meta charset="utf-8"><title>GitScribe</title></head>
+<body style="font-family: sans-serif; text-align: center; margin-top: 4em">
+<h2>%s</h2><p>%s</p>
+</body></html>`
+
+type LoginOptions struct {
+	Port    int
+	Timeout time.Duration
+	OpenURL func(authURL string) error
+}
+
+type callbackResult struct {
+	code string
+	err  error
+}
+
+func Login(ctx context.Context, provider Provider, opts LoginOptions) (*Token, error) {
+	endpoint := provider.Endpoint()
+	port := opts.Port
+	if port == 0 {
+		port = endpoint.Port
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultLoginTimeout
+	}
+	path := endpoint.CallbackPath
+	if path == "" {
+		path = defaultCallbackPath
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		if errors.Is(err, syscall.EADDRINUSE) {
+			return nil, fmt.Errorf("%w: %d", ErrPortInUse, port)
+		}
+		return nil, fmt.Errorf("failed to start callback server: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://localhost:%d%s", listener.Addr().(*net.TCPAddr).Port, path)
+
+	pkce, err := NewPKCE()
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	state, err := randomString(24)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	results := make(chan callbackResult, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		result := parseCallback(r.URL.Query(), state)
+		if errors.Is(result.err, ErrStateMismatch) {
+			logging.Warn("ignoring oauth callback with unexpected state", "provider", provider.Name())
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, callbackPage, "Login failed", "This response does not belong to the current login. Try the link printed in the terminal again.")
+			return
+		}
+		if result.err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, callbackPage, "Login failed", "You can close this window and check the terminal.")
+		} else {
+			fmt.Fprintf(w, callbackPage, "Login complete", "You can close this window and return to the terminal.")
+		}
+		select {
+		case results <- result:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := AuthorizationURL(endpoint, redirectURI, pkce, state)
+	if opts.OpenURL != nil {
+		if err := opts.OpenURL(authURL); err != nil {
+			return nil, err
+		}
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var result callbackResult
+	select {
+	case result = <-results:
+	case <-timer.C:
+		return nil, ErrTimeout
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	return Exchange(ctx, endpoint, result.code, pkce.Verifier, redirectURI, state)
+}
+
+func AuthorizationURL(endpoint Endpoint, redirectURI string, pkce PKCE, state string) string {
+	params := url.Values{}
+	for k, v := range endpoint.AuthParams {
+		params.Set(k, v)
+	}
+	params.Set("response_type", "code")
+	params.Set("client_id", endpoint.ClientID)
+	params.Set("redirect_uri", redirectURI)
+	params.Set("code_challenge", pkce.Challenge)
+	params.Set("code_challenge_method", pkce.Method)
+	params.Set("state", state)
+	if len(endpoint.Scopes) > 0 {
+		params.Set("scope", strings.Join(endpoint.Scopes, " "))
+	}
+
+	separator := "?"
+	if strings.Contains(endpoint.AuthURL, "?") {
+		separator = "&"
+	}
+	return endpoint.AuthURL + separator + params.Encode()
+}
+
+func parseCallback(query url.Values, state string) callbackResult {
+	if query.Get("state") != state {
+		return callbackResult{err: ErrStateMismatch}
+	}
+	if errCode := query.Get("error"); errCode != "" {
+		if desc := query.Get("error_description"); desc != "" {
+			errCode += ": " + desc
+		}
+		return callbackResult{err: fmt.Errorf("%w (%s)", ErrAccessDenied, errCode)}
+	}
+	code := query.Get("code")
+	if code == "" {
+		return callbackResult{err: fmt.Errorf("callback did not include an authorization code")}
+	}
+	return callbackResult{code: code}
+}
@@ -4,12 +4,26 @@ import (
 	"fmt"
 )
 
+type Endpoint struct {
+	AuthURL      string
+	TokenURL     string
+	ClientID     string
+	Scopes       []string
+	CallbackPath string
+	Port         int
+	FormEncoded  bool
+	AuthParams   map[string]string
+}
+
 type Provider interface {
 	Name() string
+	Endpoint() Endpoint
 }
 
 var (
 	ErrTimeout          = fmt.Errorf("authentication timeout")
 	ErrPortInUse        = fmt.Errorf("port already in use")
 	ErrAPIKeyGeneration = fmt.Errorf("API key generation failed")
+	ErrStateMismatch    = fmt.Errorf("authorization state mismatch")
+	ErrAccessDenied     = fmt.Errorf("authorization was denied")
 )
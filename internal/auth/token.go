@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+)
+
+const tokenRequestTimeout = 15 * time.Second
+
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitzero"`
+	Scope        string    `json:"scope,omitempty"`
+}
+
+func (t Token) Expired() bool {
+	return t.ExpiresWithin(0)
+}
+
+func (t Token) ExpiresWithin(d time.Duration) bool {
+	return !t.ExpiresAt.IsZero() && time.Until(t.ExpiresAt) < d
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	Scope        string `json:"scope"`
+}
+
+func Exchange(ctx context.Context, endpoint Endpoint, code, verifier, redirectURI, state string) (*Token, error) {
+	return requestToken(ctx, endpoint, map[string]string{
+		"grant_type":    "authorization_code",
+		"code":          code,
+		"code_verifier": verifier,
+		"redirect_uri":  redirectURI,
+		"state":         state,
+		"client_id":     endpoint.ClientID,
+	})
+}
+
+func Refresh(ctx context.Context, endpoint Endpoint, refreshToken string) (*Token, error) {
+	token, err := requestToken(ctx, endpoint, map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+		"client_id":     endpoint.ClientID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+func requestToken(ctx context.Context, endpoint Endpoint, params map[string]string) (*Token, error) {
+	var body io.Reader
+	contentType := "application/json"
+	if endpoint.FormEncoded {
+		values := url.Values{}
+		for k, v := range params {
+			values.Set(k, v)
+		}
+		body = strings.NewReader(values.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	} else {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tokenRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.TokenURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{Transport: logging.Transport(nil)}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, logging.Redact(string(data)))
+	}
+
+	var parsed tokenResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if parsed.AccessToken == "" {
+		return nil, fmt.Errorf("token endpoint returned no access token")
+	}
+
+	token := &Token{
+		AccessToken:  parsed.AccessToken,
+		RefreshToken: parsed.RefreshToken,
+		Scope:        parsed.Scope,
+	}
+	if parsed.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(parsed.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
@@ -0,0 +1,94 @@
+package catalog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+const (
+	cacheFileName   = "catalog-cache.json"
+	DefaultCacheTTL = 24 * time.Hour
+)
+
+type CachedProvider struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Models    []string  `json:"models"`
+}
+
+type Cache struct {
+	Providers map[string]CachedProvider `json:"providers"`
+	path      string
+}
+
+func getCachePath() (string, error) {
+	dir, err := config.EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, cacheFileName), nil
+}
+
+func LoadCache() (*Cache, error) {
+	path, err := getCachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &Cache{Providers: make(map[string]CachedProvider), path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, cache); err != nil {
+		return &Cache{Providers: make(map[string]CachedProvider), path: path}, nil
+	}
+	if cache.Providers == nil {
+		cache.Providers = make(map[string]CachedProvider)
+	}
+	return cache, nil
+}
+
+func (c *Cache) Save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+func (c *Cache) Get(provider string, ttl time.Duration) (CachedProvider, bool) {
+	entry, ok := c.Providers[provider]
+	if !ok {
+		return CachedProvider{}, false
+	}
+	return entry, time.Since(entry.FetchedAt) < ttl
+}
+
+func (c *Cache) Set(provider string, models []string) {
+	c.Providers[provider] = CachedProvider{
+		FetchedAt: time.Now(),
+		Models:    models,
+	}
+}
+
+func (c *Cache) Clear() error {
+	c.Providers = make(map[string]CachedProvider)
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
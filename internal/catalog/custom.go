@@ -0,0 +1,51 @@
+package catalog
+
+import (
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+)
+
+var customModels []Model
+
+func RegisterCustomProviders(defs []config.ProviderDefinition) {
+	for name, pc := range ProviderConfigs {
+		if pc.Custom {
+			delete(ProviderConfigs, name)
+		}
+	}
+	customModels = nil
+
+	for _, def := range defs {
+		method, err := ParseAuthMethod(def.AuthMethod)
+		if err != nil {
+			logging.Warn("custom provider skipped", "provider", def.Name, "error", err)
+			continue
+		}
+
+		ProviderConfigs[def.Name] = ProviderConfig{
+			Name:       def.Name,
+			BaseURL:    def.BaseURL,
+			AuthMethod: method,
+			AuthHeader: def.AuthHeader,
+			Headers:    def.Headers,
+			EnvVar:     def.EnvVar,
+			Custom:     true,
+		}
+
+		for _, id := range def.Models {
+			customModels = append(customModels, Model{
+				ID:          id,
+				Provider:    def.Name,
+				Name:        id,
+				Description: "Custom provider " + def.Name,
+			})
+		}
+	}
+}
+
+func allModels() []Model {
+	all := make([]Model, 0, len(StaticModels)+len(customModels))
+	all = append(all, StaticModels...)
+	all = append(all, customModels...)
+	return applyOverrides(all)
+}
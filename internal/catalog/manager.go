@@ -1,15 +1,38 @@
 package catalog
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/ollama"
 )
 
+type ModelLister interface {
+	ListModels(ctx context.Context, provider string) ([]string, error)
+}
+
 type CatalogManager struct {
 	apiKeyResolver func(provider string) (string, error)
+	lister         ModelLister
+	cache          *Cache
+	ttl            time.Duration
+	ollamaModels   []Model
+	ollamaFetched  bool
 }
 
 func NewCatalogManager(resolver func(string) (string, error)) *CatalogManager {
-	return &CatalogManager{apiKeyResolver: resolver}
+	return &CatalogManager{apiKeyResolver: resolver, ttl: DefaultCacheTTL}
+}
+
+func (cm *CatalogManager) WithLister(lister ModelLister) *CatalogManager {
+	cm.lister = lister
+	if cache, err := LoadCache(); err == nil {
+		cm.cache = cache
+	}
+	return cm
 }
 
 func (cm *CatalogManager) ListProviders() []string {
@@ -17,28 +40,200 @@ func (cm *CatalogManager) ListProviders() []string {
 	for k := range ProviderConfigs {
 		list = append(list, k)
 	}
+	sort.Strings(list)
 	return list
 }
 
 func (cm *CatalogManager) GetModelsByProvider(provider string) []Model {
-	var models []Model
-	for _, m := range StaticModels {
-		if m.Provider == provider {
-			models = append(models, m)
+	if provider == "ollama" {
+		if models := cm.installedOllamaModels(); len(models) > 0 {
+			return models
 		}
 	}
-	return models
+
+	if ids, ok := cm.dynamicModels(provider); ok {
+		return mergeWithStatic(provider, ids)
+	}
+
+	return staticModelsFor(provider)
+}
+
+func (cm *CatalogManager) dynamicModels(provider string) ([]string, bool) {
+	if cm.cache == nil {
+		return nil, false
+	}
+
+	entry, fresh := cm.cache.Get(provider, cm.ttl)
+	if fresh && len(entry.Models) > 0 {
+		return entry.Models, true
+	}
+
+	if cm.lister != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if ids, err := cm.lister.ListModels(ctx, provider); err == nil && len(ids) > 0 {
+			cm.cache.Set(provider, ids)
+			_ = cm.cache.Save()
+			return ids, true
+		}
+	}
+
+	if len(entry.Models) > 0 {
+		return entry.Models, true
+	}
+	return nil, false
+}
+
+func (cm *CatalogManager) Refresh(ctx context.Context, providers []string) map[string]error {
+	results := make(map[string]error, len(providers))
+	if cm.lister == nil {
+		for _, p := range providers {
+			results[p] = fmt.Errorf("no model lister configured")
+		}
+		return results
+	}
+	if cm.cache == nil {
+		cache, err := LoadCache()
+		if err != nil {
+			for _, p := range providers {
+				results[p] = fmt.Errorf("failed to load catalog cache: %w", err)
+			}
+			return results
+		}
+		cm.cache = cache
+	}
+
+	for _, p := range providers {
+		ids, err := cm.lister.ListModels(ctx, p)
+		if err == nil && len(ids) == 0 {
+			err = fmt.Errorf("provider returned no models")
+		}
+		if err != nil {
+			results[p] = err
+			continue
+		}
+		cm.cache.Set(p, ids)
+		results[p] = nil
+	}
+
+	if err := cm.cache.Save(); err != nil {
+		for p, e := range results {
+			if e == nil {
+				results[p] = fmt.Errorf("failed to save catalog cache: %w", err)
+			}
+		}
+	}
+	return results
+}
+
+func (cm *CatalogManager) LastRefresh(provider string) (time.Time, bool) {
+	if cm.cache == nil {
+		return time.Time{}, false
+	}
+	entry, ok := cm.cache.Providers[provider]
+	return entry.FetchedAt, ok
+}
+
+func (cm *CatalogManager) installedOllamaModels() []Model {
+	if !cm.ollamaFetched {
+		cm.ollamaModels, _ = cm.DiscoverOllamaModels(context.Background())
+		cm.ollamaFetched = true
+	}
+	return cm.ollamaModels
+}
+
+func (cm *CatalogManager) DiscoverOllamaModels(ctx context.Context) ([]Model, error) {
+	pConfig, _ := GetProviderConfig("ollama")
+	client := ollama.NewClient(ollama.HostFromBaseURL(pConfig.BaseURL))
+
+	installed, err := client.ListModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]Model, 0, len(installed))
+	for _, m := range installed {
+		model, ok := LookupModel("ollama", strings.TrimSuffix(m.Name, ":latest"))
+		if !ok {
+			model = Model{Provider: "ollama", Name: m.Name}
+		}
+		model.ID = m.Name
+		model.Description = "Installed locally"
+		models = append(models, model)
+	}
+	return models, nil
 }
 
 func (cm *CatalogManager) GetModel(id string) (*Model, error) {
-	for i := range StaticModels {
-		if StaticModels[i].ID == id {
-			return &StaticModels[i], nil
+	all := allModels()
+	for i := range all {
+		if all[i].ID == id {
+			return &all[i], nil
+		}
+	}
+	if cm.cache != nil {
+		for provider, entry := range cm.cache.Providers {
+			for _, cached := range entry.Models {
+				if cached == id {
+					m := Model{ID: id, Provider: provider, Name: id}
+					return &m, nil
+				}
+			}
+		}
+	}
+	for i := range cm.ollamaModels {
+		if cm.ollamaModels[i].ID == id {
+			return &cm.ollamaModels[i], nil
 		}
 	}
 	return nil, fmt.Errorf("model not found: %s", id)
 }
 
+func (cm *CatalogManager) GetModelForProvider(provider, id string) (*Model, error) {
+	for _, m := range cm.GetModelsByProvider(provider) {
+		if m.ID == id {
+			return &m, nil
+		}
+	}
+	return nil, fmt.Errorf("model %s not found for provider %s", id, provider)
+}
+
 func (cm *CatalogManager) GetProviderConfig(name string) (ProviderConfig, bool) {
 	return GetProviderConfig(name)
 }
+
+func staticModelsFor(provider string) []Model {
+	var models []Model
+	for _, m := range allModels() {
+		if m.Provider == provider {
+			models = append(models, m)
+		}
+	}
+	return models
+}
+
+func mergeWithStatic(provider string, ids []string) []Model {
+	known := make(map[string]Model)
+	for _, m := range staticModelsFor(provider) {
+		known[m.ID] = m
+	}
+
+	models := make([]Model, 0, len(ids))
+	for _, id := range ids {
+		if m, ok := known[id]; ok {
+			models = append(models, m)
+			continue
+		}
+		models = append(models, Model{ID: id, Provider: provider, Name: id})
+	}
+
+	sort.SliceStable(models, func(i, j int) bool {
+		_, iKnown := known[models[i].ID]
+		_, jKnown := known[models[j].ID]
+		if iKnown != jKnown {
+			return iKnown
+		}
+		return models[i].ID < models[j].ID
+	})
+	return models
+}
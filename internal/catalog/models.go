@@ -2,19 +2,67 @@ package catalog
 
 import (
 	"fmt"
+	"strings"
+	"time"
 )
 
 type Model struct {
-	ID          string `json:"id" yaml:"id"`
-	Provider    string `json:"provider" yaml:"provider"`
-	Name        string `json:"name" yaml:"name"`
-	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	ID                string  `json:"id" yaml:"id"`
+	Provider          string  `json:"provider" yaml:"provider"`
+	Name              string  `json:"name" yaml:"name"`
+	Description       string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Group             string  `json:"group,omitempty" yaml:"group,omitempty"`
+	ContextWindow     int     `json:"context_window,omitempty" yaml:"context_window,omitempty"`
+	MaxOutput         int     `json:"max_output,omitempty" yaml:"max_output,omitempty"`
+	InputPrice        float64 `json:"input_price,omitempty" yaml:"input_price,omitempty"`
+	OutputPrice       float64 `json:"output_price,omitempty" yaml:"output_price,omitempty"`
+	SupportsStreaming bool    `json:"supports_streaming,omitempty" yaml:"supports_streaming,omitempty"`
+	SupportsJSON      bool    `json:"supports_json,omitempty" yaml:"supports_json,omitempty"`
+	DeprecatedAt      string  `json:"deprecated_at,omitempty" yaml:"deprecated_at,omitempty"`
+}
+
+func (m Model) IsDeprecated() bool {
+	if m.DeprecatedAt == "" {
+		return false
+	}
+	date, err := time.Parse("2006-01-02", m.DeprecatedAt)
+	if err != nil {
+		return false
+	}
+	return !time.Now().Before(date)
+}
+
+func (m Model) EstimateCost(promptTokens, completionTokens int) float64 {
+	return (float64(promptTokens)*m.InputPrice + float64(completionTokens)*m.OutputPrice) / 1_000_000
 }
 
 type ProviderConfig struct {
-	Name       string     `json:"name" yaml:"name"`
-	BaseURL    string     `json:"base_url" yaml:"base_url"`
-	AuthMethod AuthMethod `json:"auth_method" yaml:"auth_method"`
+	Name           string            `json:"name" yaml:"name"`
+	DisplayName    string            `json:"display_name,omitempty" yaml:"display_name,omitempty"`
+	Icon           string            `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Description    string            `json:"description,omitempty" yaml:"description,omitempty"`
+	BaseURL        string            `json:"base_url" yaml:"base_url"`
+	AuthMethod     AuthMethod        `json:"auth_method" yaml:"auth_method"`
+	AuthHeader     string            `json:"auth_header,omitempty" yaml:"auth_header,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	EnvVar         string            `json:"env_var,omitempty" yaml:"env_var,omitempty"`
+	DefaultModel   string            `json:"default_model,omitempty" yaml:"default_model,omitempty"`
+	SupportsOAuth2 bool              `json:"supports_oauth2,omitempty" yaml:"supports_oauth2,omitempty"`
+	Custom         bool              `json:"custom,omitempty" yaml:"custom,omitempty"`
+}
+
+func (p ProviderConfig) RequiresAPIKey() bool {
+	return p.AuthMethod != AuthMethodNone && p.AuthMethod != AuthMethodSigV4
+}
+
+func (p ProviderConfig) Title() string {
+	if p.DisplayName != "" {
+		return p.DisplayName
+	}
+	if len(p.Name) > 0 {
+		return strings.ToUpper(p.Name[:1]) + strings.ToLower(p.Name[1:])
+	}
+	return p.Name
 }
 
 type AuthMethod string
@@ -23,8 +71,22 @@ const (
 	AuthMethodAPIKey AuthMethod = "api_key"
 	AuthMethodBearer AuthMethod = "bearer"
 	AuthMethodNone   AuthMethod = "none"
+	AuthMethodHeader AuthMethod = "header"
+	AuthMethodAzure  AuthMethod = "azure"
+	AuthMethodSigV4  AuthMethod = "sigv4"
 )
 
+func ParseAuthMethod(s string) (AuthMethod, error) {
+	switch AuthMethod(s) {
+	case "":
+		return AuthMethodBearer, nil
+	case AuthMethodAPIKey, AuthMethodBearer, AuthMethodNone, AuthMethodHeader, AuthMethodAzure, AuthMethodSigV4:
+		return AuthMethod(s), nil
+	default:
+		return "", fmt.Errorf("invalid auth method: %s (use bearer, api_key, header, azure, sigv4 or none)", s)
+	}
+}
+
 type ModelCatalog struct {
 	Models    []Model          `json:"models" yaml:"models"`
 	Providers []ProviderConfig `json:"providers" yaml:"providers"`
@@ -38,3 +100,10 @@ func (c *ModelCatalog) GetModelByID(id string) (*Model, error) {
 	}
 	return nil, fmt.Errorf("model not found: %s", id)
 }
+
+func ProfileName(provider, modelID string) string {
+	if len(modelID) > len(provider) && strings.HasPrefix(modelID, provider) {
+		return modelID
+	}
+	return fmt.Sprintf("%s-%s", provider, modelID)
+}
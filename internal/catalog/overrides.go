@@ -0,0 +1,162 @@
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+const OverridesFileName = "models.yaml"
+
+var overrideModels []Model
+
+func Configure(cfg *config.Config) error {
+	if cfg != nil {
+		RegisterCustomProviders(cfg.Providers)
+	}
+
+	path, err := GetOverridesPath()
+	if err != nil {
+		return err
+	}
+	return LoadOverrides(path)
+}
+
+func GetOverridesPath() (string, error) {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), OverridesFileName), nil
+}
+
+func LoadOverrides(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read model overrides: %w", err)
+	}
+
+	var overrides ModelCatalog
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return fmt.Errorf("failed to parse model overrides %s: %w", path, err)
+	}
+
+	for _, p := range overrides.Providers {
+		if p.Name == "" {
+			return fmt.Errorf("model overrides %s: provider entry without name", path)
+		}
+		ProviderConfigs[p.Name] = mergeProvider(ProviderConfigs[p.Name], p)
+	}
+
+	for _, m := range overrides.Models {
+		if m.ID == "" || m.Provider == "" {
+			return fmt.Errorf("model overrides %s: model entries need id and provider", path)
+		}
+	}
+	overrideModels = overrides.Models
+	return nil
+}
+
+func applyOverrides(models []Model) []Model {
+	if len(overrideModels) == 0 {
+		return models
+	}
+
+	merged := make([]Model, len(models))
+	copy(merged, models)
+
+	for _, o := range overrideModels {
+		found := false
+		for i := range merged {
+			if merged[i].Provider == o.Provider && merged[i].ID == o.ID {
+				merged[i] = mergeModel(merged[i], o)
+				found = true
+				break
+			}
+		}
+		if !found {
+			if o.Name == "" {
+				o.Name = o.ID
+			}
+			merged = append(merged, o)
+		}
+	}
+	return merged
+}
+
+func mergeModel(base, o Model) Model {
+	if o.Name != "" {
+		base.Name = o.Name
+	}
+	if o.Description != "" {
+		base.Description = o.Description
+	}
+	if o.Group != "" {
+		base.Group = o.Group
+	}
+	if o.ContextWindow != 0 {
+		base.ContextWindow = o.ContextWindow
+	}
+	if o.MaxOutput != 0 {
+		base.MaxOutput = o.MaxOutput
+	}
+	if o.InputPrice != 0 {
+		base.InputPrice = o.InputPrice
+	}
+	if o.OutputPrice != 0 {
+		base.OutputPrice = o.OutputPrice
+	}
+	if o.SupportsStreaming {
+		base.SupportsStreaming = true
+	}
+	if o.SupportsJSON {
+		base.SupportsJSON = true
+	}
+	if o.DeprecatedAt != "" {
+		base.DeprecatedAt = o.DeprecatedAt
+	}
+	return base
+}
+
+func mergeProvider(base, o ProviderConfig) ProviderConfig {
+	if base.Name == "" {
+		if o.AuthMethod == "" {
+			o.AuthMethod = AuthMethodBearer
+		}
+		return o
+	}
+	if o.DisplayName != "" {
+		base.DisplayName = o.DisplayName
+	}
+	if o.Icon != "" {
+		base.Icon = o.Icon
+	}
+	if o.Description != "" {
+		base.Description = o.Description
+	}
+	if o.BaseURL != "" {
+		base.BaseURL = o.BaseURL
+	}
+	if o.AuthMethod != "" {
+		base.AuthMethod = o.AuthMethod
+	}
+	if o.AuthHeader != "" {
+		base.AuthHeader = o.AuthHeader
+	}
+	if o.EnvVar != "" {
+		base.EnvVar = o.EnvVar
+	}
+	if o.DefaultModel != "" {
+		base.DefaultModel = o.DefaultModel
+	}
+	if len(o.Headers) > 0 {
+		base.Headers = o.Headers
+	}
+	return base
+}
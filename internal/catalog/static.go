@@ -2,64 +2,381 @@ package catalog
 
 var StaticModels = []Model{
 	{
-		ID:       "claude-3-5-sonnet-20241022",
-		Provider: "anthropic",
-		Name:     "Claude 3.5 Sonnet",
+		ID:                "claude-sonnet-4-5-20250929",
+		Provider:          "anthropic",
+		Name:              "Claude Sonnet 4.5",
+		Description:       "Best balance of intelligence and speed",
+		Group:             "Claude 4",
+		ContextWindow:     200000,
+		MaxOutput:         64000,
+		InputPrice:        3,
+		OutputPrice:       15,
+		SupportsStreaming: true,
 	},
 	{
-		ID:       "claude-3-5-haiku-20241022",
-		Provider: "anthropic",
-		Name:     "Claude 3.5 Haiku",
+		ID:                "claude-haiku-4-5-20251001",
+		Provider:          "anthropic",
+		Name:              "Claude Haiku 4.5",
+		Description:       "Fast and cost-effective",
+		Group:             "Claude 4",
+		ContextWindow:     200000,
+		MaxOutput:         64000,
+		InputPrice:        1,
+		OutputPrice:       5,
+		SupportsStreaming: true,
 	},
 	{
-		ID:       "gpt-4o",
-		Provider: "openai",
-		Name:     "GPT-4o",
+		ID:                "claude-sonnet-4-20250514",
+		Provider:          "anthropic",
+		Name:              "Claude Sonnet 4",
+		Description:       "Previous generation Sonnet",
+		Group:             "Claude 4",
+		ContextWindow:     200000,
+		MaxOutput:         64000,
+		InputPrice:        3,
+		OutputPrice:       15,
+		SupportsStreaming: true,
 	},
 	{
-		ID:       "gpt-4o-mini",
-		Provider: "openai",
-		Name:     "GPT-4o Mini",
+		ID:                "claude-3-5-sonnet-20241022",
+		Provider:          "anthropic",
+		Name:              "Claude 3.5 Sonnet",
+		Description:       "Legacy Sonnet model",
+		Group:             "Claude 3.5",
+		ContextWindow:     200000,
+		MaxOutput:         8192,
+		InputPrice:        3,
+		OutputPrice:       15,
+		SupportsStreaming: true,
+		DeprecatedAt:      "2025-10-22",
 	},
 	{
-		ID:          "kimi-k2.5-free",
-		Provider:    "opencode",
-		Name:        "Kimi 2.5 Free",
-		Description: "Long context specialist from OpenCode Zen",
+		ID:                "claude-3-5-haiku-20241022",
+		Provider:          "anthropic",
+		Name:              "Claude 3.5 Haiku",
+		Description:       "Legacy fast model",
+		Group:             "Claude 3.5",
+		ContextWindow:     200000,
+		MaxOutput:         8192,
+		InputPrice:        0.8,
+		OutputPrice:       4,
+		SupportsStreaming: true,
 	},
 	{
-		ID:          "minimax-m2.1-free",
-		Provider:    "opencode",
-		Name:        "MiniMax M2.1 Free",
-		Description: "Fast and lightweight coding assistant",
+		ID:                "claude-3-opus-20240229",
+		Provider:          "anthropic",
+		Name:              "Claude 3 Opus",
+		Description:       "Legacy model for complex tasks",
+		Group:             "Claude 3",
+		ContextWindow:     200000,
+		MaxOutput:         4096,
+		InputPrice:        15,
+		OutputPrice:       75,
+		SupportsStreaming: true,
+		DeprecatedAt:      "2026-01-05",
 	},
 	{
-		ID:          "glm-4.7-free",
-		Provider:    "opencode",
-		Name:        "GLM 4.7 Free",
-		Description: "Powerful General Language Model",
+		ID:                "claude-3-haiku-20240307",
+		Provider:          "anthropic",
+		Name:              "Claude 3 Haiku",
+		Description:       "Fastest, most cost-effective legacy model",
+		Group:             "Claude 3",
+		ContextWindow:     200000,
+		MaxOutput:         4096,
+		InputPrice:        0.25,
+		OutputPrice:       1.25,
+		SupportsStreaming: true,
 	},
 	{
-		ID:       "llama-3.3-70b-versatile",
-		Provider: "groq",
-		Name:     "Llama 3.3 70B Versatile",
+		ID:                "gpt-4.1",
+		Provider:          "openai",
+		Name:              "GPT-4.1",
+		Description:       "Long-context flagship model",
+		Group:             "GPT-4.1",
+		ContextWindow:     1047576,
+		MaxOutput:         32768,
+		InputPrice:        2,
+		OutputPrice:       8,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
 	},
 	{
-		ID:       "openai/gpt-oss-120b",
-		Provider: "groq",
-		Name:     "OpenAI GPT OSS 120b",
+		ID:                "gpt-4.1-mini",
+		Provider:          "openai",
+		Name:              "GPT-4.1 Mini",
+		Description:       "Fast, affordable long-context model",
+		Group:             "GPT-4.1",
+		ContextWindow:     1047576,
+		MaxOutput:         32768,
+		InputPrice:        0.4,
+		OutputPrice:       1.6,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
 	},
 	{
-		ID:          "moonshotai/kimi-k2.5",
-		Provider:    "hackclub",
-		Name:        "Kimi K2.5",
-		Description: "Reasoning model via Hack Club",
+		ID:                "gpt-4o",
+		Provider:          "openai",
+		Name:              "GPT-4o",
+		Description:       "Capable multimodal model",
+		Group:             "GPT-4",
+		ContextWindow:     128000,
+		MaxOutput:         16384,
+		InputPrice:        2.5,
+		OutputPrice:       10,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
 	},
 	{
-		ID:          "qwen/qwen-2.5-72b-instruct",
-		Provider:    "hackclub",
-		Name:        "Qwen 2.5 72B",
-		Description: "Powerful open model via Hack Club",
+		ID:                "gpt-4o-mini",
+		Provider:          "openai",
+		Name:              "GPT-4o Mini",
+		Description:       "Fast, affordable for most tasks",
+		Group:             "GPT-4",
+		ContextWindow:     128000,
+		MaxOutput:         16384,
+		InputPrice:        0.15,
+		OutputPrice:       0.6,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
+	},
+	{
+		ID:                "gpt-4-turbo",
+		Provider:          "openai",
+		Name:              "GPT-4 Turbo",
+		Description:       "Legacy high quality model",
+		Group:             "GPT-4",
+		ContextWindow:     128000,
+		MaxOutput:         4096,
+		InputPrice:        10,
+		OutputPrice:       30,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
+	},
+	{
+		ID:            "o1",
+		Provider:      "openai",
+		Name:          "o1",
+		Description:   "Advanced reasoning model",
+		Group:         "o1 Series",
+		ContextWindow: 200000,
+		MaxOutput:     100000,
+		InputPrice:    15,
+		OutputPrice:   60,
+		SupportsJSON:  true,
+	},
+	{
+		ID:                "llama-3.3-70b-versatile",
+		Provider:          "groq",
+		Name:              "Llama 3.3 70B Versatile",
+		Description:       "Ultra-fast inference with Llama",
+		Group:             "Llama 3",
+		ContextWindow:     131072,
+		MaxOutput:         32768,
+		InputPrice:        0.59,
+		OutputPrice:       0.79,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
+	},
+	{
+		ID:                "openai/gpt-oss-120b",
+		Provider:          "groq",
+		Name:              "OpenAI GPT OSS 120b",
+		Description:       "Open-weight reasoning model on Groq",
+		Group:             "GPT OSS",
+		ContextWindow:     131072,
+		MaxOutput:         65536,
+		InputPrice:        0.15,
+		OutputPrice:       0.75,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
+	},
+	{
+		ID:                "mixtral-8x7b-32768",
+		Provider:          "groq",
+		Name:              "Mixtral 8x7B",
+		Description:       "Efficient MoE architecture",
+		Group:             "Mixtral",
+		ContextWindow:     32768,
+		MaxOutput:         32768,
+		InputPrice:        0.24,
+		OutputPrice:       0.24,
+		SupportsStreaming: true,
+		DeprecatedAt:      "2025-03-20",
+	},
+	{
+		ID:                "gemini-2.5-flash",
+		Provider:          "gemini",
+		Name:              "Gemini 2.5 Flash",
+		Description:       "Fast multimodal model",
+		Group:             "Gemini 2.5",
+		ContextWindow:     1048576,
+		MaxOutput:         65536,
+		InputPrice:        0.3,
+		OutputPrice:       2.5,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
+	},
+	{
+		ID:                "gemini-2.5-pro",
+		Provider:          "gemini",
+		Name:              "Gemini 2.5 Pro",
+		Description:       "Most capable Gemini model",
+		Group:             "Gemini 2.5",
+		ContextWindow:     1048576,
+		MaxOutput:         65536,
+		InputPrice:        1.25,
+		OutputPrice:       10,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
+	},
+	{
+		ID:                "llama3.2",
+		Provider:          "ollama",
+		Name:              "Llama 3.2",
+		Description:       "Meta's compact local model",
+		Group:             "Llama",
+		ContextWindow:     131072,
+		MaxOutput:         4096,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
+	},
+	{
+		ID:                "qwen2.5-coder",
+		Provider:          "ollama",
+		Name:              "Qwen 2.5 Coder",
+		Description:       "Local model tuned for code",
+		Group:             "Code",
+		ContextWindow:     32768,
+		MaxOutput:         4096,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
+	},
+	{
+		ID:                "codellama",
+		Provider:          "ollama",
+		Name:              "CodeLlama",
+		Description:       "Specialized for coding tasks",
+		Group:             "Code",
+		ContextWindow:     16384,
+		MaxOutput:         4096,
+		SupportsStreaming: true,
+	},
+	{
+		ID:                "mistral",
+		Provider:          "ollama",
+		Name:              "Mistral",
+		Description:       "Efficient open model",
+		Group:             "Mistral",
+		ContextWindow:     32768,
+		MaxOutput:         4096,
+		SupportsStreaming: true,
+	},
+	{
+		ID:                "anthropic/claude-sonnet-4.5",
+		Provider:          "openrouter",
+		Name:              "Claude Sonnet 4.5",
+		Description:       "Via OpenRouter",
+		Group:             "Anthropic",
+		ContextWindow:     200000,
+		MaxOutput:         64000,
+		InputPrice:        3,
+		OutputPrice:       15,
+		SupportsStreaming: true,
+	},
+	{
+		ID:                "openai/gpt-4o",
+		Provider:          "openrouter",
+		Name:              "GPT-4o",
+		Description:       "Via OpenRouter",
+		Group:             "OpenAI",
+		ContextWindow:     128000,
+		MaxOutput:         16384,
+		InputPrice:        2.5,
+		OutputPrice:       10,
+		SupportsStreaming: true,
+		SupportsJSON:      true,
+	},
+	{
+		ID:                "meta-llama/llama-3.3-70b-instruct",
+		Provider:          "openrouter",
+		Name:              "Llama 3.3 70B",
+		Description:       "Via OpenRouter",
+		Group:             "Meta",
+		ContextWindow:     131072,
+		MaxOutput:         16384,
+		InputPrice:        0.13,
+		OutputPrice:       0.4,
+		SupportsStreaming: true,
+	},
+	{
+		ID:            "anthropic.claude-3-5-sonnet-20241022-v2:0",
+		Provider:      "bedrock",
+		Name:          "Claude 3.5 Sonnet v2 (Bedrock)",
+		Group:         "Claude 3.5",
+		ContextWindow: 200000,
+		MaxOutput:     8192,
+		InputPrice:    3,
+		OutputPrice:   15,
+	},
+	{
+		ID:            "anthropic.claude-3-5-haiku-20241022-v1:0",
+		Provider:      "bedrock",
+		Name:          "Claude 3.5 Haiku (Bedrock)",
+		Group:         "Claude 3.5",
+		ContextWindow: 200000,
+		MaxOutput:     8192,
+		InputPrice:    0.8,
+		OutputPrice:   4,
+	},
+	{
+		ID:                "kimi-k2.5-free",
+		Provider:          "opencode",
+		Name:              "Kimi 2.5 Free",
+		Description:       "Long context specialist from OpenCode Zen",
+		Group:             "OpenCode Zen",
+		ContextWindow:     262144,
+		MaxOutput:         32768,
+		SupportsStreaming: true,
+	},
+	{
+		ID:                "minimax-m2.1-free",
+		Provider:          "opencode",
+		Name:              "MiniMax M2.1 Free",
+		Description:       "Fast and lightweight coding assistant",
+		Group:             "OpenCode Zen",
+		ContextWindow:     204800,
+		MaxOutput:         32768,
+		SupportsStreaming: true,
+	},
+	{
+		ID:                "glm-4.7-free",
+		Provider:          "opencode",
+		Name:              "GLM 4.7 Free",
+		Description:       "Powerful General Language Model",
+		Group:             "OpenCode Zen",
+		ContextWindow:     204800,
+		MaxOutput:         32768,
+		SupportsStreaming: true,
+	},
+	{
+		ID:                "moonshotai/kimi-k2.5",
+		Provider:          "hackclub",
+		Name:              "Kimi K2.5",
+		Description:       "Reasoning model via Hack Club",
+		Group:             "Hack Club",
+		ContextWindow:     262144,
+		MaxOutput:         32768,
+		SupportsStreaming: true,
+	},
+	{
+		ID:                "qwen/qwen-2.5-72b-instruct",
+		Provider:          "hackclub",
+		Name:              "Qwen 2.5 72B",
+		Description:       "Powerful open model via Hack Club",
+		Group:             "Hack Club",
+		ContextWindow:     32768,
+		MaxOutput:         8192,
+		SupportsStreaming: true,
 	},
 }
 
@@ -69,29 +386,100 @@ func GetStaticModels() []Model {
 
 var ProviderConfigs = map[string]ProviderConfig{
 	"anthropic": {
-		Name:       "anthropic",
-		BaseURL:    "https://api.anthropic.com/v1",
-		AuthMethod: AuthMethodAPIKey,
+		Name:           "anthropic",
+		DisplayName:    "Anthropic",
+		Icon:           "🧠",
+		Description:    "Claude models with excellent reasoning",
+		BaseURL:        "https://api.anthropic.com/v1",
+		AuthMethod:     AuthMethodAPIKey,
+		EnvVar:         "ANTHROPIC_API_KEY",
+		DefaultModel:   "claude-sonnet-4-5-20250929",
+		SupportsOAuth2: true,
 	},
 	"openai": {
-		Name:       "openai",
-		BaseURL:    "https://api.openai.com/v1",
-		AuthMethod: AuthMethodBearer,
+		Name:         "openai",
+		DisplayName:  "OpenAI",
+		Icon:         "🤖",
+		Description:  "GPT models, fast and capable",
+		BaseURL:      "https://api.openai.com/v1",
+		AuthMethod:   AuthMethodBearer,
+		EnvVar:       "OPENAI_API_KEY",
+		DefaultModel: "gpt-4o",
 	},
 	"groq": {
-		Name:       "groq",
-		BaseURL:    "https://api.groq.com/openai/v1",
-		AuthMethod: AuthMethodBearer,
+		Name:         "groq",
+		DisplayName:  "GROQ",
+		Icon:         "⚡",
+		Description:  "Ultra-fast Llama inference",
+		BaseURL:      "https://api.groq.com/openai/v1",
+		AuthMethod:   AuthMethodBearer,
+		EnvVar:       "GROQ_API_KEY",
+		DefaultModel: "llama-3.3-70b-versatile",
+	},
+	"gemini": {
+		Name:         "gemini",
+		DisplayName:  "Gemini",
+		Icon:         "🔮",
+		Description:  "Google multimodal models",
+		BaseURL:      "https://generativelanguage.googleapis.com/v1beta/openai",
+		AuthMethod:   AuthMethodBearer,
+		EnvVar:       "GEMINI_API_KEY",
+		DefaultModel: "gemini-2.5-flash",
+	},
+	"openrouter": {
+		Name:         "openrouter",
+		DisplayName:  "OpenRouter",
+		Icon:         "🌐",
+		Description:  "Multi-provider access",
+		BaseURL:      "https://openrouter.ai/api/v1",
+		AuthMethod:   AuthMethodBearer,
+		EnvVar:       "OPENROUTER_API_KEY",
+		DefaultModel: "anthropic/claude-sonnet-4.5",
 	},
 	"opencode": {
-		Name:       "opencode",
-		BaseURL:    "https://opencode.ai/zen/v1",
-		AuthMethod: AuthMethodAPIKey,
+		Name:         "opencode",
+		DisplayName:  "OpenCode",
+		Icon:         "💻",
+		Description:  "OpenCode Zen models",
+		BaseURL:      "https://opencode.ai/zen/v1",
+		AuthMethod:   AuthMethodAPIKey,
+		EnvVar:       "OPENCODE_API_KEY",
+		DefaultModel: "kimi-k2.5-free",
 	},
 	"hackclub": {
-		Name:       "hackclub",
-		BaseURL:    "https://ai.hackclub.com/proxy/v1",
-		AuthMethod: AuthMethodBearer,
+		Name:         "hackclub",
+		DisplayName:  "Hack Club",
+		Icon:         "🚩",
+		Description:  "Hack Club AI proxy",
+		BaseURL:      "https://ai.hackclub.com/proxy/v1",
+		AuthMethod:   AuthMethodBearer,
+		EnvVar:       "HACKCLUB_API_KEY",
+		DefaultModel: "moonshotai/kimi-k2.5",
+	},
+	"ollama": {
+		Name:         "ollama",
+		DisplayName:  "Ollama",
+		Icon:         "🦙",
+		Description:  "Local self-hosted models",
+		BaseURL:      "http://localhost:11434/v1",
+		AuthMethod:   AuthMethodNone,
+		DefaultModel: "llama3.2",
+	},
+	"azure": {
+		Name:        "azure",
+		DisplayName: "Azure OpenAI",
+		Icon:        "☁️",
+		Description: "OpenAI models on your Azure deployments",
+		AuthMethod:  AuthMethodAzure,
+		EnvVar:      "AZURE_OPENAI_API_KEY",
+	},
+	"bedrock": {
+		Name:         "bedrock",
+		DisplayName:  "AWS Bedrock",
+		Icon:         "🪨",
+		Description:  "Anthropic models through AWS Bedrock",
+		AuthMethod:   AuthMethodSigV4,
+		DefaultModel: "anthropic.claude-3-5-sonnet-20241022-v2:0",
 	},
 }
 
@@ -99,3 +487,32 @@ func GetProviderConfig(name string) (ProviderConfig, bool) {
 	config, ok := ProviderConfigs[name]
 	return config, ok
 }
+
+func RequiresAPIKey(provider string) bool {
+	config, ok := ProviderConfigs[provider]
+	if !ok {
+		return true
+	}
+	return config.RequiresAPIKey()
+}
+
+func DefaultModel(provider string) string {
+	if config, ok := ProviderConfigs[provider]; ok && config.DefaultModel != "" {
+		return config.DefaultModel
+	}
+	for _, m := range allModels() {
+		if m.Provider == provider && !m.IsDeprecated() {
+			return m.ID
+		}
+	}
+	return ""
+}
+
+func LookupModel(provider, id string) (Model, bool) {
+	for _, m := range allModels() {
+		if m.Provider == provider && m.ID == id {
+			return m, true
+		}
+	}
+	return Model{}, false
+}
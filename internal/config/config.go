@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -24,20 +25,52 @@ const (
 	ProviderOpenRouter AgentProvider = "openrouter"
 	ProviderOpenCode   AgentProvider = "opencode"
 	ProviderHackClub   AgentProvider = "hackclub"
+	ProviderAzure      AgentProvider = "azure"
+	ProviderBedrock    AgentProvider = "bedrock"
 )
 
 type AgentProfile struct {
-	Name         string        `yaml:"name" json:"name"`
-	Provider     AgentProvider `yaml:"provider" json:"provider"`
-	Model        string        `yaml:"model" json:"model"`
-	BaseURL      string        `yaml:"base_url,omitempty" json:"base_url,omitempty"`
-	Temperature  float32       `yaml:"temperature" json:"temperature"`
-	MaxTokens    int           `yaml:"max_tokens" json:"max_tokens"`
-	Timeout      int           `yaml:"timeout_seconds" json:"timeout_seconds"`
-	Enabled      bool          `yaml:"enabled" json:"enabled"`
-	Priority     int           `yaml:"priority" json:"priority"`
-	SystemPrompt string        `yaml:"system_prompt,omitempty" json:"system_prompt,omitempty"`
-	KeyringKey   string        `yaml:"keyring_key" json:"keyring_key"`
+	Name           string            `yaml:"name" json:"name"`
+	Provider       AgentProvider     `yaml:"provider" json:"provider"`
+	Model          string            `yaml:"model" json:"model"`
+	BaseURL        string            `yaml:"base_url,omitempty" json:"base_url,omitempty"`
+	Temperature    float32           `yaml:"temperature" json:"temperature"`
+	MaxTokens      int               `yaml:"max_tokens" json:"max_tokens"`
+	Timeout        int               `yaml:"timeout_seconds" json:"timeout_seconds"`
+	Enabled        bool              `yaml:"enabled" json:"enabled"`
+	Priority       int               `yaml:"priority" json:"priority"`
+	SystemPrompt   string            `yaml:"system_prompt,omitempty" json:"system_prompt,omitempty"`
+	KeyringKey     string            `yaml:"keyring_key" json:"keyring_key"`
+	KeySource      string            `yaml:"key_source,omitempty" json:"key_source,omitempty"`
+	APIVersion     string            `yaml:"api_version,omitempty" json:"api_version,omitempty"`
+	Deployment     string            `yaml:"deployment,omitempty" json:"deployment,omitempty"`
+	Region         string            `yaml:"region,omitempty" json:"region,omitempty"`
+	Headers        map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	Proxy          string            `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+	CACertFile     string            `yaml:"ca_cert_file,omitempty" json:"ca_cert_file,omitempty"`
+	ClientCertFile string            `yaml:"client_cert_file,omitempty" json:"client_cert_file,omitempty"`
+	ClientKeyFile  string            `yaml:"client_key_file,omitempty" json:"client_key_file,omitempty"`
+	MonthlyBudget  float64           `yaml:"monthly_budget,omitempty" json:"monthly_budget,omitempty"`
+}
+
+type ProviderDefinition struct {
+	Name       string            `yaml:"name" json:"name"`
+	BaseURL    string            `yaml:"base_url" json:"base_url"`
+	AuthMethod string            `yaml:"auth_method,omitempty" json:"auth_method,omitempty"`
+	AuthHeader string            `yaml:"auth_header,omitempty" json:"auth_header,omitempty"`
+	Headers    map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	EnvVar     string            `yaml:"env_var,omitempty" json:"env_var,omitempty"`
+	Models     []string          `yaml:"models,omitempty" json:"models,omitempty"`
+}
+
+type OAuthClient struct {
+	Name         string   `yaml:"name" json:"name"`
+	ClientID     string   `yaml:"client_id" json:"client_id"`
+	AuthURL      string   `yaml:"auth_url" json:"auth_url"`
+	TokenURL     string   `yaml:"token_url" json:"token_url"`
+	Scopes       []string `yaml:"scopes,omitempty" json:"scopes,omitempty"`
+	CallbackPath string   `yaml:"callback_path,omitempty" json:"callback_path,omitempty"`
+	Port         int      `yaml:"port,omitempty" json:"port,omitempty"`
 }
 
 type RoutingRule struct {
@@ -54,18 +87,50 @@ type GlobalConfig struct {
 	MaxRetries     int               `yaml:"max_retries" json:"max_retries"`
 	LogLevel       string            `yaml:"log_level" json:"log_level"`
 	CustomHeaders  map[string]string `yaml:"custom_headers,omitempty" json:"custom_headers,omitempty"`
+	Proxy          string            `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+	CACertFile     string            `yaml:"ca_cert_file,omitempty" json:"ca_cert_file,omitempty"`
+	ClientCertFile string            `yaml:"client_cert_file,omitempty" json:"client_cert_file,omitempty"`
+	ClientKeyFile  string            `yaml:"client_key_file,omitempty" json:"client_key_file,omitempty"`
+	BudgetAction   string            `yaml:"budget_action,omitempty" json:"budget_action,omitempty"`
+	SecretsBackend string            `yaml:"secrets_backend,omitempty" json:"secrets_backend,omitempty"`
+	SecretScan     string            `yaml:"secret_scan,omitempty" json:"secret_scan,omitempty"`
 }
 
+const (
+	BudgetActionWarn  = "warn"
+	BudgetActionBlock = "block"
+)
+
+const (
+	SecretsBackendAuto    = "auto"
+	SecretsBackendKeyring = "keyring"
+	SecretsBackendFile    = "file"
+)
+
+const (
+	SecretScanRedact = "redact"
+	SecretScanStrict = "strict"
+	SecretScanOff    = "off"
+)
+
+const (
+	KeySourceCommand = "command"
+	KeySourceFile    = "file"
+	KeySourceEnv     = "env"
+)
+
 type Config struct {
-	Version string         `yaml:"version" json:"version"`
-	Global  GlobalConfig   `yaml:"global" json:"global"`
-	Agents  []AgentProfile `yaml:"agents" json:"agents"`
-	Routing []RoutingRule  `yaml:"routing" json:"routing"`
+	Version   string               `yaml:"version" json:"version"`
+	Global    GlobalConfig         `yaml:"global" json:"global"`
+	Agents    []AgentProfile       `yaml:"agents" json:"agents"`
+	Routing   []RoutingRule        `yaml:"routing" json:"routing"`
+	Providers []ProviderDefinition `yaml:"providers,omitempty" json:"providers,omitempty"`
+	OAuth     []OAuthClient        `yaml:"oauth,omitempty" json:"oauth,omitempty"`
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		Version: "1.0",
+		Version: CurrentConfigVersion,
 		Global: GlobalConfig{
 			DefaultAgent:   "groq-default",
 			AutoSelect:     true,
@@ -84,7 +149,7 @@ func DefaultConfig() *Config {
 				Enabled:      true,
 				Priority:     1,
 				SystemPrompt: "You are a helpful assistant.",
-				KeyringKey:   "groq-default-api-key",
+				KeyringKey:   "agent:groq-default:api-key",
 			},
 			{
 				Name:         "openai-gpt4",
@@ -96,7 +161,7 @@ func DefaultConfig() *Config {
 				Enabled:      false,
 				Priority:     2,
 				SystemPrompt: "You are a helpful assistant.",
-				KeyringKey:   "openai-gpt4-api-key",
+				KeyringKey:   "agent:openai-gpt4:api-key",
 			},
 			{
 				Name:         "claude-sonnet",
@@ -108,7 +173,7 @@ func DefaultConfig() *Config {
 				Enabled:      false,
 				Priority:     3,
 				SystemPrompt: "You are a helpful assistant with strong reasoning capabilities.",
-				KeyringKey:   "claude-sonnet-api-key",
+				KeyringKey:   "agent:claude-sonnet:api-key",
 			},
 		},
 		Routing: []RoutingRule{
@@ -129,12 +194,7 @@ func DefaultConfig() *Config {
 }
 
 func GetConfigPath() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
-	}
-	configDir := filepath.Join(home, ConfigDirName)
-	return filepath.Join(configDir, ConfigFileName), nil
+	return ProfilePath(ActiveProfile())
 }
 
 func EnsureConfigDir() (string, error) {
@@ -150,19 +210,15 @@ func EnsureConfigDir() (string, error) {
 }
 
 func Load() (*Config, error) {
-	configPath, err := GetConfigPath()
+	result, err := MigrateConfigFile(true)
 	if err != nil {
 		return nil, err
 	}
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	if result.Data == nil {
 		return DefaultConfig(), nil
 	}
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
 	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	if err := yaml.Unmarshal(result.Data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 	return &cfg, nil
@@ -177,6 +233,13 @@ func (c *Config) Save() error {
 	if err != nil {
 		return err
 	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := backupOutdated(configPath, MigrateConfigData); err != nil {
+		return err
+	}
+	c.Version = CurrentConfigVersion
 	data, err := yaml.Marshal(c)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
@@ -243,3 +306,98 @@ func (c *Config) SetDefaultAgent(name string) error {
 	c.Global.DefaultAgent = name
 	return nil
 }
+
+func (c *Config) GetProvider(name string) (*ProviderDefinition, error) {
+	for i := range c.Providers {
+		if c.Providers[i].Name == name {
+			return &c.Providers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("custom provider not found: %s", name)
+}
+
+func (c *Config) AddProvider(provider ProviderDefinition) error {
+	if provider.Name == "" {
+		return fmt.Errorf("provider name cannot be empty")
+	}
+	if provider.BaseURL == "" {
+		return fmt.Errorf("base URL is required for provider: %s", provider.Name)
+	}
+	if IsBuiltinProvider(AgentProvider(provider.Name)) {
+		return fmt.Errorf("provider name is reserved: %s", provider.Name)
+	}
+	if _, err := c.GetProvider(provider.Name); err == nil {
+		return fmt.Errorf("custom provider already exists: %s", provider.Name)
+	}
+	c.Providers = append(c.Providers, provider)
+	return nil
+}
+
+func (c *Config) RemoveProvider(name string) error {
+	for _, agent := range c.Agents {
+		if string(agent.Provider) == name {
+			return fmt.Errorf("provider %s is still used by agent %s", name, agent.Name)
+		}
+	}
+	for i, provider := range c.Providers {
+		if provider.Name == name {
+			c.Providers = append(c.Providers[:i], c.Providers[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("custom provider not found: %s", name)
+}
+
+func (c *Config) GetOAuthClient(name string) (*OAuthClient, bool) {
+	for i := range c.OAuth {
+		if c.OAuth[i].Name == name {
+			return &c.OAuth[i], true
+		}
+	}
+	return nil, false
+}
+
+func (c *Config) SetOAuthClient(client OAuthClient) {
+	if existing, ok := c.GetOAuthClient(client.Name); ok {
+		*existing = client
+		return
+	}
+	c.OAuth = append(c.OAuth, client)
+}
+
+func BuiltinProviders() []AgentProvider {
+	return []AgentProvider{
+		ProviderOpenAI,
+		ProviderGroq,
+		ProviderClaude,
+		ProviderGemini,
+		ProviderOllama,
+		ProviderOpenRouter,
+		ProviderOpenCode,
+		ProviderHackClub,
+		ProviderAzure,
+		ProviderBedrock,
+	}
+}
+
+func ParseKeySource(ref string) (string, string, error) {
+	kind, value, ok := strings.Cut(ref, ":")
+	value = strings.TrimSpace(value)
+	if !ok || value == "" {
+		return "", "", fmt.Errorf("invalid key source %q (use command:<cmd>, file:<path> or env:<VAR>)", ref)
+	}
+	switch kind {
+	case KeySourceCommand, KeySourceFile, KeySourceEnv:
+		return kind, value, nil
+	}
+	return "", "", fmt.Errorf("unknown key source type %q (use command, file or env)", kind)
+}
+
+func IsBuiltinProvider(provider AgentProvider) bool {
+	for _, p := range BuiltinProviders() {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
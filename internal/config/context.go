@@ -14,31 +14,34 @@ const (
 )
 
 type ContextEntry struct {
-	Text      string    `json:"text"`
-	CreatedAt time.Time `json:"created_at"`
+	Text      string    `json:"text" yaml:"text"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
 }
 
 type ContextManager struct {
+	Version  int                       `json:"version,omitempty"`
 	Contexts map[string][]ContextEntry `json:"contexts"`
 }
 
-func getContextsPath() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".config", "gitscribe", contextsFileName)
+func ContextsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "gitscribe", contextsFileName), nil
 }
 
 func LoadContexts() (*ContextManager, error) {
-	path := getContextsPath()
-	data, err := os.ReadFile(path)
+	result, err := MigrateContextsFile(true)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return &ContextManager{Contexts: make(map[string][]ContextEntry)}, nil
-		}
 		return nil, err
 	}
+	if result.Data == nil {
+		return &ContextManager{Version: CurrentContextsVersion, Contexts: make(map[string][]ContextEntry)}, nil
+	}
 
 	var cm ContextManager
-	if err := json.Unmarshal(data, &cm); err != nil {
+	if err := json.Unmarshal(result.Data, &cm); err != nil {
 		return nil, err
 	}
 
@@ -50,12 +53,19 @@ func LoadContexts() (*ContextManager, error) {
 }
 
 func (cm *ContextManager) Save() error {
-	path := getContextsPath()
+	path, err := ContextsPath()
+	if err != nil {
+		return err
+	}
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
+	if err := backupOutdated(path, MigrateContextsData); err != nil {
+		return err
+	}
+	cm.Version = CurrentContextsVersion
 	data, err := json.MarshalIndent(cm, "", "  ")
 	if err != nil {
 		return err
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const ExportFormatVersion = "1"
+
+var secretHeaderPattern = regexp.MustCompile(`(?i)(auth|key|token|secret|cookie|password)`)
+
+type RequiredSecret struct {
+	Path        string `yaml:"path"`
+	Agent       string `yaml:"agent,omitempty"`
+	Provider    string `yaml:"provider,omitempty"`
+	Description string `yaml:"description"`
+}
+
+type ExportBundle struct {
+	Format          string                    `yaml:"format"`
+	ExportedAt      time.Time                 `yaml:"exported_at"`
+	Config          Config                    `yaml:"config"`
+	Contexts        map[string][]ContextEntry `yaml:"contexts,omitempty"`
+	RequiredSecrets []RequiredSecret          `yaml:"required_secrets,omitempty"`
+}
+
+func NewExportBundle(cfg *Config) (*ExportBundle, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	bundle := &ExportBundle{Format: ExportFormatVersion, ExportedAt: time.Now()}
+	if err := yaml.Unmarshal(data, &bundle.Config); err != nil {
+		return nil, fmt.Errorf("failed to copy config: %w", err)
+	}
+
+	c := &bundle.Config
+	bundle.stripHeaders(c.Global.CustomHeaders, "global.custom_headers", "", "")
+	bundle.stripProxy(&c.Global.Proxy, "global.proxy", "")
+	for i := range c.Providers {
+		p := &c.Providers[i]
+		bundle.stripHeaders(p.Headers, "providers."+p.Name+".headers", "", p.Name)
+	}
+	for i := range c.Agents {
+		a := &c.Agents[i]
+		bundle.stripHeaders(a.Headers, "agents."+a.Name+".headers", a.Name, string(a.Provider))
+		bundle.stripProxy(&a.Proxy, "agents."+a.Name+".proxy", a.Name)
+	}
+	return bundle, nil
+}
+
+func (b *ExportBundle) Require(secret RequiredSecret) {
+	b.RequiredSecrets = append(b.RequiredSecrets, secret)
+}
+
+func (b *ExportBundle) stripHeaders(headers map[string]string, path, agent, provider string) {
+	var names []string
+	for name := range headers {
+		if secretHeaderPattern.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		delete(headers, name)
+		b.Require(RequiredSecret{
+			Path:        path + "." + name,
+			Agent:       agent,
+			Provider:    provider,
+			Description: fmt.Sprintf("header %s was left out, set it with 'gs config set %s.%s <value>'", name, path, name),
+		})
+	}
+}
+
+func (b *ExportBundle) stripProxy(proxy *string, path, agent string) {
+	if *proxy == "" {
+		return
+	}
+	u, err := url.Parse(*proxy)
+	if err != nil || u.User == nil {
+		return
+	}
+	u.User = nil
+	*proxy = u.String()
+	b.Require(RequiredSecret{
+		Path:        path,
+		Agent:       agent,
+		Description: fmt.Sprintf("proxy credentials were left out, set them with 'gs config set %s <url>'", path),
+	})
+}
+
+func LoadExportBundle(data []byte) (*ExportBundle, error) {
+	var bundle ExportBundle
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse export file: %w", err)
+	}
+	if bundle.Format != ExportFormatVersion {
+		return nil, fmt.Errorf("unsupported export format %q", bundle.Format)
+	}
+
+	raw, err := yaml.Marshal(&bundle.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal imported config: %w", err)
+	}
+	migrated, _, err := MigrateConfigData(raw)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(migrated, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse imported config: %w", err)
+	}
+	bundle.Config = cfg
+	return &bundle, nil
+}
+
+func (c *Config) Merge(other *Config) []string {
+	var added []string
+	for _, p := range other.Providers {
+		if _, err := c.GetProvider(p.Name); err != nil {
+			c.Providers = append(c.Providers, p)
+			added = append(added, "provider "+p.Name)
+		}
+	}
+	for _, a := range other.Agents {
+		if _, err := c.GetAgentByName(a.Name); err != nil {
+			c.Agents = append(c.Agents, a)
+			added = append(added, "agent "+a.Name)
+		}
+	}
+	if c.Global.DefaultAgent == "" {
+		c.Global.DefaultAgent = other.Global.DefaultAgent
+	}
+	return added
+}
+
+func BackupConfigFile() (string, error) {
+	path, err := GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read config file: %w", err)
+	}
+	return backupFile(path, data)
+}
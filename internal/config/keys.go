@@ -0,0 +1,370 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type KeyValue struct {
+	Key    string
+	Value  string
+	Secret bool
+}
+
+type keyRef struct {
+	value  reflect.Value
+	mapKey string
+	mapVal reflect.Value
+	isMap  bool
+	path   string
+}
+
+var readOnlyKeys = map[string]bool{"version": true}
+
+var entryNamePattern = regexp.MustCompile(`^(agents|providers)\[\d+\]\.name$`)
+
+func (c *Config) Get(key string) (string, error) {
+	ref, err := c.resolve(key)
+	if err != nil {
+		return "", err
+	}
+	if ref.isMap {
+		v := ref.mapVal.MapIndex(reflect.ValueOf(ref.mapKey))
+		if !v.IsValid() {
+			return "", fmt.Errorf("key not set: %s", key)
+		}
+		return formatValue(v), nil
+	}
+	return formatValue(ref.value), nil
+}
+
+func (c *Config) Set(key, value string) (string, error) {
+	if readOnlyKeys[key] {
+		return "", fmt.Errorf("%s is managed by gs config migrate", key)
+	}
+	ref, err := c.resolve(key)
+	if err != nil {
+		return "", err
+	}
+	if err := checkWritable(key, ref.path); err != nil {
+		return "", err
+	}
+	if ref.isMap {
+		if ref.mapVal.IsNil() {
+			ref.mapVal.Set(reflect.MakeMap(ref.mapVal.Type()))
+		}
+		ref.mapVal.SetMapIndex(reflect.ValueOf(ref.mapKey), reflect.ValueOf(value))
+		return ref.path, nil
+	}
+	if err := setValue(ref.value, value); err != nil {
+		return "", fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	return ref.path, nil
+}
+
+func (c *Config) Unset(key string) (string, error) {
+	if readOnlyKeys[key] {
+		return "", fmt.Errorf("%s is managed by gs config migrate", key)
+	}
+	ref, err := c.resolve(key)
+	if err != nil {
+		return "", err
+	}
+	if err := checkWritable(key, ref.path); err != nil {
+		return "", err
+	}
+	if ref.isMap {
+		if !ref.mapVal.IsNil() {
+			ref.mapVal.SetMapIndex(reflect.ValueOf(ref.mapKey), reflect.Value{})
+		}
+		return ref.path, nil
+	}
+	if ref.value.Kind() == reflect.Slice && ref.value.Type().Elem().Kind() == reflect.Struct {
+		return "", fmt.Errorf("cannot unset %s, remove entries with the agent or provider commands", key)
+	}
+	ref.value.Set(reflect.Zero(ref.value.Type()))
+	return ref.path, nil
+}
+
+func checkWritable(key, path string) error {
+	if entryNamePattern.MatchString(path) {
+		kind := strings.TrimSuffix(path[:strings.IndexByte(path, '[')], "s")
+		return fmt.Errorf("%s is read-only, remove the %s and add it again under the new name", key, kind)
+	}
+	return nil
+}
+
+func (c *Config) List() []KeyValue {
+	var out []KeyValue
+	flatten(reflect.ValueOf(c).Elem(), "", &out)
+	return out
+}
+
+func (c *Config) resolve(key string) (*keyRef, error) {
+	if key == "" {
+		return nil, fmt.Errorf("key cannot be empty")
+	}
+	segments, err := splitKey(key)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(c).Elem()
+	path := ""
+
+	for i := 0; i < len(segments); i++ {
+		seg := segments[i]
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByTag(v, seg)
+			if !ok {
+				return nil, fmt.Errorf("unknown key: %s", strings.Join(segments[:i+1], "."))
+			}
+			v = field
+			path = joinPath(path, seg)
+		case reflect.Slice:
+			if v.Type().Elem().Kind() != reflect.Struct {
+				return nil, fmt.Errorf("%s is a list and has no sub-keys", path)
+			}
+			idx, n, ok := sliceEntry(v, segments[i:])
+			if !ok {
+				return nil, fmt.Errorf("no entry named %q in %s", seg, path)
+			}
+			i += n - 1
+			v = v.Index(idx)
+			path = fmt.Sprintf("%s[%d]", path, idx)
+		case reflect.Map:
+			mapKey := strings.Join(segments[i:], ".")
+			return &keyRef{mapVal: v, mapKey: mapKey, isMap: true, path: joinPath(path, keySegment(mapKey))}, nil
+		default:
+			return nil, fmt.Errorf("%s has no sub-keys", path)
+		}
+	}
+
+	if v.Kind() == reflect.Struct {
+		return nil, fmt.Errorf("%s is a section, use a more specific key", key)
+	}
+	return &keyRef{value: v, path: path}, nil
+}
+
+func splitKey(key string) ([]string, error) {
+	var segments []string
+	var current strings.Builder
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; c {
+		case '.':
+			if current.Len() == 0 && (i == 0 || key[i-1] != ']') {
+				return nil, fmt.Errorf("invalid key: %s", key)
+			}
+			if current.Len() > 0 {
+				segments = append(segments, current.String())
+				current.Reset()
+			}
+		case '[':
+			if current.Len() > 0 {
+				segments = append(segments, current.String())
+				current.Reset()
+			}
+			end := strings.IndexByte(key[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid key: %s (missing ])", key)
+			}
+			name := key[i+1 : i+end]
+			if len(name) >= 2 && (name[0] == '"' || name[0] == '\'') && name[len(name)-1] == name[0] {
+				name = name[1 : len(name)-1]
+			}
+			if name == "" {
+				return nil, fmt.Errorf("invalid key: %s (empty [])", key)
+			}
+			segments = append(segments, name)
+			i += end
+			if i+1 < len(key) && key[i+1] != '.' && key[i+1] != '[' {
+				return nil, fmt.Errorf("invalid key: %s", key)
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if current.Len() > 0 {
+		segments = append(segments, current.String())
+	} else if strings.HasSuffix(key, ".") {
+		return nil, fmt.Errorf("invalid key: %s", key)
+	}
+	return segments, nil
+}
+
+func sliceEntry(v reflect.Value, segments []string) (int, int, bool) {
+	for n := len(segments); n > 0; n-- {
+		if idx, ok := sliceIndex(v, strings.Join(segments[:n], ".")); ok {
+			return idx, n, true
+		}
+	}
+	return 0, 0, false
+}
+
+func keySegment(name string) string {
+	if strings.ContainsAny(name, ".[]") {
+		return strconv.Quote(name)
+	}
+	return name
+}
+
+func fieldByTag(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if yamlName(t.Field(i)) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func yamlName(f reflect.StructField) string {
+	tag := f.Tag.Get("yaml")
+	if tag == "" || tag == "-" {
+		return ""
+	}
+	return strings.Split(tag, ",")[0]
+}
+
+func sliceIndex(v reflect.Value, seg string) (int, bool) {
+	for i := 0; i < v.Len(); i++ {
+		if name := v.Index(i).FieldByName("Name"); name.IsValid() && name.String() == seg {
+			return i, true
+		}
+	}
+	if idx, err := strconv.Atoi(seg); err == nil && idx >= 0 && idx < v.Len() {
+		return idx, true
+	}
+	return 0, false
+}
+
+func joinPath(path, seg string) string {
+	if strings.HasPrefix(seg, `"`) {
+		return path + "[" + seg + "]"
+	}
+	if path == "" {
+		return seg
+	}
+	return path + "." + seg
+}
+
+func setValue(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("expected true or false, got %q", value)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("expected an integer, got %q", value)
+		}
+		v.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("expected a number, got %q", value)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("cannot set a list of entries directly")
+		}
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	case reflect.Map:
+		return fmt.Errorf("set individual entries with a sub-key")
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Struct {
+			var names []string
+			for i := 0; i < v.Len(); i++ {
+				if name := v.Index(i).FieldByName("Name"); name.IsValid() {
+					names = append(names, name.String())
+				}
+			}
+			return strings.Join(names, ",")
+		}
+		var items []string
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, fmt.Sprint(v.Index(i).Interface()))
+		}
+		return strings.Join(items, ",")
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		var items []string
+		for _, k := range keys {
+			items = append(items, fmt.Sprintf("%s=%v", k.String(), v.MapIndex(k).Interface()))
+		}
+		return strings.Join(items, ",")
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func flatten(v reflect.Value, prefix string, out *[]KeyValue) {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name := yamlName(t.Field(i))
+			if name == "" {
+				continue
+			}
+			flatten(v.Field(i), joinPath(prefix, name), out)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Struct {
+			if v.Len() > 0 {
+				*out = append(*out, KeyValue{Key: prefix, Value: formatValue(v)})
+			}
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			seg := strconv.Itoa(i)
+			if name := v.Index(i).FieldByName("Name"); name.IsValid() && name.String() != "" {
+				seg = keySegment(name.String())
+			}
+			flatten(v.Index(i), joinPath(prefix, seg), out)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			*out = append(*out, KeyValue{
+				Key:    joinPath(prefix, keySegment(k.String())),
+				Value:  formatValue(v.MapIndex(k)),
+				Secret: secretHeaderPattern.MatchString(k.String()),
+			})
+		}
+	default:
+		if !v.IsZero() {
+			*out = append(*out, KeyValue{Key: prefix, Value: formatValue(v)})
+		}
+	}
+}
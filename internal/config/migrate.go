@@ -0,0 +1,303 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	CurrentConfigVersion   = "1.1"
+	CurrentContextsVersion = 1
+)
+
+type Migration struct {
+	From        string
+	To          string
+	Description string
+	Apply       func(doc map[string]any) error
+}
+
+var configMigrations = []Migration{
+	{
+		From:        "1.0",
+		To:          "1.1",
+		Description: "normalize agent keyring keys to agent:<name>:api-key and default log_level to info",
+		Apply:       migrateConfig10To11,
+	},
+}
+
+var contextsMigrations = []Migration{
+	{
+		From:        "0",
+		To:          "1",
+		Description: "add a version field and merge contexts stored under equivalent project paths",
+		Apply:       migrateContexts0To1,
+	},
+}
+
+func migrateConfig10To11(doc map[string]any) error {
+	if agents, ok := doc["agents"].([]any); ok {
+		for _, item := range agents {
+			agent, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := agent["name"].(string)
+			key, _ := agent["keyring_key"].(string)
+			if name != "" && key == name+"-api-key" {
+				agent["keyring_key"] = fmt.Sprintf("agent:%s:api-key", name)
+			}
+		}
+	}
+
+	global, ok := doc["global"].(map[string]any)
+	if !ok {
+		global = map[string]any{}
+		doc["global"] = global
+	}
+	if level, _ := global["log_level"].(string); level == "" {
+		global["log_level"] = "info"
+	}
+	return nil
+}
+
+func migrateContexts0To1(doc map[string]any) error {
+	contexts, ok := doc["contexts"].(map[string]any)
+	if !ok {
+		doc["contexts"] = map[string]any{}
+		return nil
+	}
+
+	merged := make(map[string]any)
+	for path, entries := range contexts {
+		list, ok := entries.([]any)
+		if !ok {
+			return fmt.Errorf("contexts.%s: expected a list of contexts", path)
+		}
+		key := path
+		if key != "" {
+			key = filepath.Clean(key)
+		}
+		existing, _ := merged[key].([]any)
+		for _, entry := range list {
+			if !containsContext(existing, entry) {
+				existing = append(existing, entry)
+			}
+		}
+		merged[key] = existing
+	}
+	doc["contexts"] = merged
+	return nil
+}
+
+func containsContext(list []any, entry any) bool {
+	text := contextText(entry)
+	for _, e := range list {
+		if contextText(e) == text {
+			return true
+		}
+	}
+	return false
+}
+
+func contextText(entry any) string {
+	if m, ok := entry.(map[string]any); ok {
+		text, _ := m["text"].(string)
+		return text
+	}
+	return ""
+}
+
+func pendingMigrations(migrations []Migration, version, current string) ([]Migration, error) {
+	var pending []Migration
+	for version != current {
+		found := false
+		for _, m := range migrations {
+			if m.From == version {
+				pending = append(pending, m)
+				version = m.To
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unsupported version %q (this version of gs supports up to %s)", version, current)
+		}
+	}
+	return pending, nil
+}
+
+func configVersion(doc map[string]any) string {
+	switch v := doc["version"].(type) {
+	case string:
+		if v != "" {
+			return v
+		}
+	case float64:
+		version := strconv.FormatFloat(v, 'f', -1, 64)
+		if !strings.Contains(version, ".") {
+			version += ".0"
+		}
+		return version
+	case int:
+		return fmt.Sprintf("%d.0", v)
+	}
+	return "1.0"
+}
+
+func MigrateConfigData(data []byte) ([]byte, []Migration, error) {
+	doc := map[string]any{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	pending, err := pendingMigrations(configMigrations, configVersion(doc), CurrentConfigVersion)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot migrate config: %w", err)
+	}
+	if len(pending) == 0 {
+		return data, nil, nil
+	}
+
+	for _, m := range pending {
+		if err := m.Apply(doc); err != nil {
+			return nil, nil, fmt.Errorf("config migration %s -> %s failed: %w", m.From, m.To, err)
+		}
+		doc["version"] = m.To
+	}
+
+	var cfg Config
+	raw, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal migrated config: %w", err)
+	}
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse migrated config: %w", err)
+	}
+	out, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal migrated config: %w", err)
+	}
+	return out, pending, nil
+}
+
+func MigrateContextsData(data []byte) ([]byte, []Migration, error) {
+	doc := map[string]any{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse contexts file: %w", err)
+	}
+
+	version := "0"
+	if v, ok := doc["version"].(float64); ok {
+		version = fmt.Sprintf("%d", int(v))
+	}
+	pending, err := pendingMigrations(contextsMigrations, version, fmt.Sprintf("%d", CurrentContextsVersion))
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot migrate contexts: %w", err)
+	}
+	if len(pending) == 0 {
+		return data, nil, nil
+	}
+
+	for _, m := range pending {
+		if err := m.Apply(doc); err != nil {
+			return nil, nil, fmt.Errorf("contexts migration %s -> %s failed: %w", m.From, m.To, err)
+		}
+	}
+	doc["version"] = CurrentContextsVersion
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal migrated contexts: %w", err)
+	}
+	return out, pending, nil
+}
+
+type MigrationResult struct {
+	Path       string
+	BackupPath string
+	Applied    []Migration
+	Data       []byte
+}
+
+func MigrateConfigFile(dryRun bool) (*MigrationResult, error) {
+	path, err := GetConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return migrateFile(path, MigrateConfigData, dryRun)
+}
+
+func MigrateContextsFile(dryRun bool) (*MigrationResult, error) {
+	path, err := ContextsPath()
+	if err != nil {
+		return nil, err
+	}
+	return migrateFile(path, MigrateContextsData, dryRun)
+}
+
+func migrateFile(path string, migrate func([]byte) ([]byte, []Migration, error), dryRun bool) (*MigrationResult, error) {
+	result := &MigrationResult{Path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	out, applied, err := migrate(data)
+	if err != nil {
+		return nil, err
+	}
+	result.Applied = applied
+	result.Data = out
+	if len(applied) == 0 || dryRun {
+		return result, nil
+	}
+
+	result.BackupPath, err = backupFile(path, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(path, out, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write migrated %s: %w", path, err)
+	}
+	return result, nil
+}
+
+func backupOutdated(path string, migrate func([]byte) ([]byte, []Migration, error)) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	if _, applied, err := migrate(data); err != nil || len(applied) == 0 {
+		return nil
+	}
+	_, err = backupFile(path, data)
+	return err
+}
+
+func backupFile(path string, data []byte) (string, error) {
+	backup := fmt.Sprintf("%s.%s.bak", path, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(backup, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return backup, nil
+}
+
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,277 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DefaultProfile   = "default"
+	ProfileEnvVar    = "GS_PROFILE"
+	profilesFileName = "profiles.yaml"
+	profilesDirName  = "profiles"
+)
+
+type Profile struct {
+	Name       string   `yaml:"name" json:"name"`
+	Paths      []string `yaml:"paths,omitempty" json:"paths,omitempty"`
+	Remotes    []string `yaml:"remotes,omitempty" json:"remotes,omitempty"`
+	GlobalKeys bool     `yaml:"global_keys,omitempty" json:"global_keys,omitempty"`
+}
+
+type ProfileIndex struct {
+	Active   string    `yaml:"active,omitempty" json:"active,omitempty"`
+	Profiles []Profile `yaml:"profiles,omitempty" json:"profiles,omitempty"`
+}
+
+var (
+	profileOverride string
+	profileRepo     string
+	profileRemote   string
+	resolvedProfile string
+	resolvedReason  string
+)
+
+var profileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+func SetProfileOverride(name string) {
+	profileOverride = name
+	resolvedProfile = ""
+}
+
+func SetProfileContext(repoPath, remoteURL string) {
+	profileRepo = repoPath
+	profileRemote = remoteURL
+	resolvedProfile = ""
+}
+
+func ActiveProfile() string {
+	name, _ := ResolveProfile()
+	return name
+}
+
+func UseDefaultProfile(reason string) {
+	resolvedProfile, resolvedReason = DefaultProfile, reason
+}
+
+func ResolveProfile() (string, string) {
+	if resolvedProfile != "" {
+		return resolvedProfile, resolvedReason
+	}
+	resolvedProfile, resolvedReason = resolveProfile()
+	return resolvedProfile, resolvedReason
+}
+
+func resolveProfile() (string, string) {
+	if profileOverride != "" {
+		return profileOverride, "--profile flag"
+	}
+	if name := strings.TrimSpace(os.Getenv(ProfileEnvVar)); name != "" {
+		return name, ProfileEnvVar + " environment variable"
+	}
+
+	index, err := LoadProfiles()
+	if err != nil {
+		return DefaultProfile, "profiles file could not be read"
+	}
+	if p, rule, ok := index.Match(profileRepo, profileRemote); ok {
+		return p.Name, "matched " + rule
+	}
+	if index.Active != "" {
+		return index.Active, "selected with gs profile switch"
+	}
+	return DefaultProfile, "default"
+}
+
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (use letters, digits, - and _)", name)
+	}
+	return nil
+}
+
+func ProfilePath(name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	if name == "" || name == DefaultProfile {
+		return filepath.Join(home, ConfigDirName, ConfigFileName), nil
+	}
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ConfigDirName, profilesDirName, name+".yaml"), nil
+}
+
+func getProfilesPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ConfigDirName, profilesFileName), nil
+}
+
+func LoadProfiles() (*ProfileIndex, error) {
+	path, err := getProfilesPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &ProfileIndex{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles file: %w", err)
+	}
+
+	var index ProfileIndex
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles file: %w", err)
+	}
+	return &index, nil
+}
+
+func (p *ProfileIndex) Save() error {
+	if _, err := EnsureConfigDir(); err != nil {
+		return err
+	}
+	path, err := getProfilesPath()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal profiles: %w", err)
+	}
+	if err := writeFileAtomic(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write profiles file: %w", err)
+	}
+	resolvedProfile = ""
+	return nil
+}
+
+func (p *ProfileIndex) Get(name string) (*Profile, bool) {
+	for i := range p.Profiles {
+		if p.Profiles[i].Name == name {
+			return &p.Profiles[i], true
+		}
+	}
+	return nil, false
+}
+
+func (p *ProfileIndex) Names() []string {
+	names := []string{DefaultProfile}
+	for _, profile := range p.Profiles {
+		if profile.Name != DefaultProfile {
+			names = append(names, profile.Name)
+		}
+	}
+	return names
+}
+
+func (p *ProfileIndex) Exists(name string) bool {
+	if name == DefaultProfile {
+		return true
+	}
+	_, ok := p.Get(name)
+	return ok
+}
+
+func (p *ProfileIndex) Remove(name string) {
+	for i := range p.Profiles {
+		if p.Profiles[i].Name == name {
+			p.Profiles = append(p.Profiles[:i], p.Profiles[i+1:]...)
+			break
+		}
+	}
+	if p.Active == name {
+		p.Active = ""
+	}
+}
+
+func (p *ProfileIndex) Match(repoPath, remoteURL string) (*Profile, string, bool) {
+	remote := NormalizeRemote(remoteURL)
+	for i := range p.Profiles {
+		profile := &p.Profiles[i]
+		if repoPath != "" {
+			for _, pattern := range profile.Paths {
+				if MatchGlob(expandHome(pattern), repoPath) {
+					return profile, "path " + pattern, true
+				}
+			}
+		}
+		if remote != "" {
+			for _, pattern := range profile.Remotes {
+				if MatchGlob(NormalizeRemote(pattern), remote) {
+					return profile, "remote " + pattern, true
+				}
+			}
+		}
+	}
+	return nil, "", false
+}
+
+func NormalizeRemote(remote string) string {
+	remote = strings.TrimSpace(remote)
+	if i := strings.Index(remote, "://"); i >= 0 {
+		remote = remote[i+3:]
+	}
+	if i := strings.Index(remote, "@"); i >= 0 && !strings.Contains(remote[:i], "/") {
+		remote = remote[i+1:]
+	}
+	if i := strings.Index(remote, ":"); i >= 0 && !strings.Contains(remote[:i], "/") {
+		rest := remote[i+1:]
+		if j := strings.Index(rest, "/"); j > 0 && isDigits(rest[:j]) {
+			rest = rest[j+1:]
+		}
+		remote = remote[:i] + "/" + rest
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(remote, "/"), ".git")
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func MatchGlob(pattern, value string) bool {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("(/.*)?$")
+	re, err := regexp.Compile(b.String())
+	return err == nil && re.MatchString(value)
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
@@ -0,0 +1,317 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+type Issue struct {
+	Path     string   `json:"path"`
+	Line     int      `json:"line,omitempty"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (i Issue) String() string {
+	location := i.Path
+	if i.Line > 0 {
+		location = fmt.Sprintf("line %d: %s", i.Line, i.Path)
+	}
+	if location == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", location, i.Message)
+}
+
+func HasErrors(issues []Issue) bool {
+	for _, i := range issues {
+		if i.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+var validSecretsBackends = map[string]bool{"": true, SecretsBackendAuto: true, SecretsBackendKeyring: true, SecretsBackendFile: true}
+
+var validSecretScanModes = map[string]bool{"": true, SecretScanRedact: true, SecretScanStrict: true, SecretScanOff: true}
+
+var validAuthMethods = map[string]bool{"": true, "bearer": true, "api_key": true, "header": true, "azure": true, "sigv4": true, "none": true}
+
+var validLogLevels = map[string]bool{"": true, "debug": true, "info": true, "warn": true, "warning": true, "error": true}
+
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (\S+) not found in type config\.(\w+)$`)
+
+func Validate(data []byte) []Issue {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return []Issue{yamlErrorIssue(err)}
+	}
+	lines := nodeLines(&root)
+	v := &validator{lines: lines}
+
+	var cfg Config
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return []Issue{yamlErrorIssue(err)}
+		}
+		for _, msg := range typeErr.Errors {
+			if m := unknownFieldPattern.FindStringSubmatch(msg); m != nil {
+				line, _ := strconv.Atoi(m[1])
+				v.issues = append(v.issues, Issue{Path: m[2], Line: line, Severity: SeverityWarning, Message: fmt.Sprintf("unknown field in %s (it is ignored)", m[3])})
+				continue
+			}
+			v.issues = append(v.issues, Issue{Severity: SeverityError, Message: msg})
+		}
+		cfg = Config{}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return v.issues
+		}
+	}
+
+	v.validate(&cfg)
+	return v.issues
+}
+
+type validator struct {
+	lines  map[string]int
+	issues []Issue
+}
+
+func (v *validator) add(severity Severity, path, format string, args ...any) {
+	line := v.lines[path]
+	for p := path; line == 0 && p != ""; {
+		if i := strings.LastIndexAny(p, ".["); i > 0 {
+			p = p[:i]
+		} else {
+			p = ""
+		}
+		line = v.lines[p]
+	}
+	v.issues = append(v.issues, Issue{Path: path, Line: line, Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validate(cfg *Config) {
+	if cfg.Version == "" {
+		v.add(SeverityWarning, "version", "missing, assuming 1.0")
+	} else if _, err := pendingMigrations(configMigrations, cfg.Version, CurrentConfigVersion); err != nil {
+		v.add(SeverityError, "version", "%v", err)
+	} else if cfg.Version != CurrentConfigVersion {
+		v.add(SeverityWarning, "version", "config is at version %s, run 'gs config migrate' to upgrade to %s", cfg.Version, CurrentConfigVersion)
+	}
+
+	if !validLogLevels[strings.ToLower(cfg.Global.LogLevel)] {
+		v.add(SeverityError, "global.log_level", "must be one of debug, info, warn or error, got %q", cfg.Global.LogLevel)
+	}
+	if cfg.Global.BudgetAction != "" && cfg.Global.BudgetAction != BudgetActionWarn && cfg.Global.BudgetAction != BudgetActionBlock {
+		v.add(SeverityError, "global.budget_action", "must be %q or %q, got %q", BudgetActionWarn, BudgetActionBlock, cfg.Global.BudgetAction)
+	}
+	if !validSecretsBackends[cfg.Global.SecretsBackend] {
+		v.add(SeverityError, "global.secrets_backend", "must be %q, %q or %q, got %q", SecretsBackendAuto, SecretsBackendKeyring, SecretsBackendFile, cfg.Global.SecretsBackend)
+	}
+	if !validSecretScanModes[cfg.Global.SecretScan] {
+		v.add(SeverityError, "global.secret_scan", "must be %q, %q or %q, got %q", SecretScanRedact, SecretScanStrict, SecretScanOff, cfg.Global.SecretScan)
+	}
+	if cfg.Global.RequestTimeout < 0 {
+		v.add(SeverityError, "global.request_timeout_seconds", "must not be negative")
+	}
+	if cfg.Global.MaxRetries < 0 {
+		v.add(SeverityError, "global.max_retries", "must not be negative")
+	}
+	if cfg.Global.ClientCertFile != "" && cfg.Global.ClientKeyFile == "" {
+		v.add(SeverityError, "global.client_key_file", "is required when client_cert_file is set")
+	}
+
+	providers := make(map[string]bool)
+	for i, p := range cfg.Providers {
+		path := fmt.Sprintf("providers[%d]", i)
+		switch {
+		case p.Name == "":
+			v.add(SeverityError, path+".name", "is required")
+		case IsBuiltinProvider(AgentProvider(p.Name)):
+			v.add(SeverityError, path+".name", "%q is a built-in provider name", p.Name)
+		case providers[p.Name]:
+			v.add(SeverityError, path+".name", "duplicate provider %q", p.Name)
+		}
+		providers[p.Name] = true
+		if p.BaseURL == "" {
+			v.add(SeverityError, path+".base_url", "is required")
+		}
+		if !validAuthMethods[p.AuthMethod] {
+			v.add(SeverityError, path+".auth_method", "must be bearer, api_key, header, azure, sigv4 or none, got %q", p.AuthMethod)
+		}
+	}
+
+	clients := make(map[string]bool)
+	for i, c := range cfg.OAuth {
+		path := fmt.Sprintf("oauth[%d]", i)
+		if c.Name == "" {
+			v.add(SeverityError, path+".name", "is required")
+		} else if clients[c.Name] {
+			v.add(SeverityError, path+".name", "duplicate OAuth client %q", c.Name)
+		}
+		clients[c.Name] = true
+		if c.ClientID == "" {
+			v.add(SeverityError, path+".client_id", "is required")
+		}
+		for _, field := range [][2]string{{"auth_url", c.AuthURL}, {"token_url", c.TokenURL}} {
+			if u, err := url.Parse(field[1]); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				v.add(SeverityError, path+"."+field[0], "must be an http or https URL, got %q", field[1])
+			}
+		}
+		if c.Port < 0 || c.Port > 65535 {
+			v.add(SeverityError, path+".port", "must be between 0 and 65535")
+		}
+	}
+
+	agents := make(map[string]bool)
+	for i, a := range cfg.Agents {
+		path := fmt.Sprintf("agents[%d]", i)
+		if a.Name == "" {
+			v.add(SeverityError, path+".name", "is required")
+		} else if agents[a.Name] {
+			v.add(SeverityError, path+".name", "duplicate agent %q", a.Name)
+		}
+		agents[a.Name] = true
+
+		if a.Provider == "" {
+			v.add(SeverityError, path+".provider", "is required")
+		} else if !IsBuiltinProvider(a.Provider) && !providers[string(a.Provider)] {
+			v.add(SeverityError, path+".provider", "unknown provider %q (add it with 'gs provider add')", a.Provider)
+		}
+		if a.Model == "" && a.Deployment == "" {
+			v.add(SeverityError, path+".model", "is required")
+		}
+		if a.Temperature < 0 || a.Temperature > 2 {
+			v.add(SeverityWarning, path+".temperature", "%.2f is outside the usual 0-2 range", a.Temperature)
+		}
+		if a.MaxTokens < 0 {
+			v.add(SeverityError, path+".max_tokens", "must not be negative")
+		}
+		if a.Timeout < 0 {
+			v.add(SeverityError, path+".timeout_seconds", "must not be negative")
+		}
+		if a.MonthlyBudget < 0 {
+			v.add(SeverityError, path+".monthly_budget", "must not be negative")
+		}
+		if a.ClientCertFile != "" && a.ClientKeyFile == "" {
+			v.add(SeverityError, path+".client_key_file", "is required when client_cert_file is set")
+		}
+		if a.Provider == ProviderAzure && a.Deployment == "" && a.Model == "" {
+			v.add(SeverityError, path+".deployment", "is required for azure")
+		}
+		if a.Provider == ProviderBedrock && a.Region == "" {
+			v.add(SeverityWarning, path+".region", "not set, the AWS default region will be used")
+		}
+		if a.KeySource != "" {
+			if _, _, err := ParseKeySource(a.KeySource); err != nil {
+				v.add(SeverityError, path+".key_source", "%v", err)
+			}
+		}
+		if a.KeyringKey != "" && a.Name != "" && a.KeyringKey != fmt.Sprintf("agent:%s:api-key", a.Name) && a.KeyringKey != fmt.Sprintf("provider:%s:api-key", a.Provider) {
+			v.add(SeverityWarning, path+".keyring_key", "%q does not match agent:%s:api-key or provider:%s:api-key, which is where keys are read from", a.KeyringKey, a.Name, a.Provider)
+		}
+	}
+
+	if cfg.Global.DefaultAgent != "" {
+		if agent, err := cfg.GetAgentByName(cfg.Global.DefaultAgent); err != nil {
+			v.add(SeverityError, "global.default_agent", "agent %q does not exist", cfg.Global.DefaultAgent)
+		} else if !agent.Enabled {
+			v.add(SeverityWarning, "global.default_agent", "agent %q is disabled", cfg.Global.DefaultAgent)
+		}
+	} else if len(cfg.ListEnabledAgents()) == 0 {
+		v.add(SeverityError, "agents", "no default agent and no enabled agents")
+	}
+
+	for i, r := range cfg.Routing {
+		if r.AgentProfile != "" && !agents[r.AgentProfile] {
+			v.add(SeverityWarning, fmt.Sprintf("routing[%d].agent_profile", i), "agent %q does not exist", r.AgentProfile)
+		}
+	}
+}
+
+func ValidateContexts(data []byte) []Issue {
+	var cm ContextManager
+	if err := json.Unmarshal(data, &cm); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return []Issue{{Severity: SeverityError, Message: fmt.Sprintf("invalid JSON at byte %d: %v", syntaxErr.Offset, err)}}
+		}
+		return []Issue{{Severity: SeverityError, Message: err.Error()}}
+	}
+
+	var issues []Issue
+	if cm.Version < CurrentContextsVersion {
+		issues = append(issues, Issue{Path: "version", Severity: SeverityWarning, Message: fmt.Sprintf("contexts are at version %d, run 'gs config migrate' to upgrade to %d", cm.Version, CurrentContextsVersion)})
+	} else if cm.Version > CurrentContextsVersion {
+		issues = append(issues, Issue{Path: "version", Severity: SeverityError, Message: fmt.Sprintf("unsupported version %d (this version of gs supports up to %d)", cm.Version, CurrentContextsVersion)})
+	}
+	for path, entries := range cm.Contexts {
+		if len(entries) > MaxContextsPerPath {
+			issues = append(issues, Issue{Path: fmt.Sprintf("contexts[%q]", path), Severity: SeverityWarning, Message: fmt.Sprintf("%d contexts, only %d are allowed per project", len(entries), MaxContextsPerPath)})
+		}
+		for i, e := range entries {
+			if strings.TrimSpace(e.Text) == "" {
+				issues = append(issues, Issue{Path: fmt.Sprintf("contexts[%q][%d].text", path, i), Severity: SeverityWarning, Message: "empty context"})
+			}
+		}
+	}
+	return issues
+}
+
+var yamlLinePattern = regexp.MustCompile(`line (\d+)`)
+
+func yamlErrorIssue(err error) Issue {
+	issue := Issue{Severity: SeverityError, Message: err.Error()}
+	if m := yamlLinePattern.FindStringSubmatch(err.Error()); m != nil {
+		issue.Line, _ = strconv.Atoi(m[1])
+	}
+	return issue
+}
+
+func nodeLines(root *yaml.Node) map[string]int {
+	lines := make(map[string]int)
+	var walk func(n *yaml.Node, path string)
+	walk = func(n *yaml.Node, path string) {
+		switch n.Kind {
+		case yaml.DocumentNode:
+			for _, c := range n.Content {
+				walk(c, path)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				key := n.Content[i].Value
+				if path != "" {
+					key = path + "." + key
+				}
+				lines[key] = n.Content[i].Line
+				walk(n.Content[i+1], key)
+			}
+		case yaml.SequenceNode:
+			for i, c := range n.Content {
+				key := fmt.Sprintf("%s[%d]", path, i)
+				lines[key] = c.Line
+				walk(c, key)
+			}
+		}
+	}
+	walk(root, "")
+	return lines
+}
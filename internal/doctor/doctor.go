@@ -0,0 +1,374 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/agents"
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+const reachabilityTimeout = 5 * time.Second
+
+type Check struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+func (r Report) Count(status Status) int {
+	count := 0
+	for _, c := range r.Checks {
+		if c.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+func (r Report) Failed() bool {
+	return r.Count(StatusFail) > 0
+}
+
+func (r *Report) add(name string, status Status, message, hint string) {
+	r.Checks = append(r.Checks, Check{Name: name, Status: status, Message: message, Hint: hint})
+}
+
+func Run(ctx context.Context) Report {
+	var report Report
+
+	checkGit(&report)
+	profile, reason := config.ResolveProfile()
+	report.add("profile", StatusPass, fmt.Sprintf("%s (%s)", profile, reason), "")
+	cfg := checkConfig(&report)
+	checkKeyring(&report)
+	checkCLIs(&report)
+	if cfg != nil {
+		catalog.RegisterCustomProviders(cfg.Providers)
+		checkDefaultAgent(&report, cfg)
+		checkAgentKeys(&report, cfg)
+		checkReachability(ctx, &report, cfg)
+	}
+	checkCatalog(&report)
+
+	return report
+}
+
+var gitVersionPattern = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+func checkGit(report *Report) {
+	output, err := exec.Command("git", "--version").Output()
+	if err != nil {
+		report.add("git", StatusFail, "git is not installed or not in PATH", "Install git from https://git-scm.com/downloads")
+		return
+	}
+	version := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(output)), "git version"))
+	if m := gitVersionPattern.FindStringSubmatch(version); m != nil && atoi(m[1]) < 2 {
+		report.add("git", StatusWarn, fmt.Sprintf("git %s is older than 2.0", version), "Upgrade git to 2.0 or later")
+	} else {
+		report.add("git", StatusPass, "git "+version, "")
+	}
+
+	if err := exec.Command("git", "rev-parse", "--is-inside-work-tree").Run(); err != nil {
+		report.add("repository", StatusWarn, "not inside a git repository", "Run gs from inside a git work tree to commit or open PRs")
+		return
+	}
+
+	branch, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		report.add("repository", StatusWarn, "repository has no commits yet", "Create an initial commit before using gs pr")
+		return
+	}
+	message := fmt.Sprintf("on branch %s", strings.TrimSpace(string(branch)))
+	if gitDir, err := exec.Command("git", "rev-parse", "--git-dir").Output(); err == nil {
+		dir := strings.TrimSpace(string(gitDir))
+		for _, state := range []string{"MERGE_HEAD", "rebase-merge", "rebase-apply", "CHERRY_PICK_HEAD"} {
+			if _, err := os.Stat(dir + "/" + state); err == nil {
+				report.add("repository", StatusWarn, message+fmt.Sprintf(", %s in progress", strings.ToLower(strings.TrimSuffix(state, "_HEAD"))), "Finish or abort the operation before committing")
+				return
+			}
+		}
+	}
+	if err := exec.Command("git", "remote", "get-url", "origin").Run(); err != nil {
+		report.add("repository", StatusWarn, message+", no origin remote", "Add a remote with 'git remote add origin <url>' to push and open PRs")
+		return
+	}
+	report.add("repository", StatusPass, message, "")
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func checkConfig(report *Report) *config.Config {
+	path, err := config.GetConfigPath()
+	if err != nil {
+		report.add("config", StatusFail, fmt.Sprintf("cannot locate config: %v", err), "Check that $HOME is set")
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		report.add("config", StatusWarn, fmt.Sprintf("%s does not exist, using defaults", path), "Run 'gs init' to create it")
+		return config.DefaultConfig()
+	}
+	if err != nil {
+		report.add("config", StatusFail, fmt.Sprintf("cannot read %s: %v", path, err), "Check the file permissions")
+		return nil
+	}
+
+	var cfg config.Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		report.add("config", StatusFail, fmt.Sprintf("%s is not valid YAML: %v", path, err), "Fix the syntax error or move the file away and run 'gs init'")
+		return nil
+	}
+	issues := config.Validate(data)
+	if config.HasErrors(issues) {
+		for _, issue := range issues {
+			if issue.Severity == config.SeverityError {
+				report.add("config", StatusFail, issue.String(), "Run 'gs config validate' to list every problem")
+				break
+			}
+		}
+		return &cfg
+	}
+	if cfg.Version != config.CurrentConfigVersion {
+		report.add("config", StatusWarn, fmt.Sprintf("%s uses schema %s", path, cfg.Version), "Run 'gs config migrate' to upgrade it")
+		return &cfg
+	}
+	if len(cfg.Agents) == 0 {
+		report.add("config", StatusWarn, fmt.Sprintf("%s has no agents", path), "Add one with 'gs agent add' or 'gs models'")
+		return &cfg
+	}
+	report.add("config", StatusPass, fmt.Sprintf("%s (%d agents)", path, len(cfg.Agents)), "")
+	return &cfg
+}
+
+func checkDefaultAgent(report *Report, cfg *config.Config) {
+	name := cfg.Global.DefaultAgent
+	if name == "" {
+		report.add("default agent", StatusFail, "no default agent is set", "Choose one with 'gs models'")
+		return
+	}
+	agent, err := cfg.GetAgentByName(name)
+	if err != nil {
+		report.add("default agent", StatusFail, fmt.Sprintf("default agent %q does not exist", name), "Choose another with 'gs models' or add it with 'gs agent add'")
+		return
+	}
+	if !agent.Enabled {
+		report.add("default agent", StatusFail, fmt.Sprintf("default agent %q is disabled", name), "Enable it in the config file or pick another with 'gs models'")
+		return
+	}
+	report.add("default agent", StatusPass, fmt.Sprintf("%s (%s/%s)", agent.Name, agent.Provider, agent.Model), "")
+}
+
+func checkKeyring(report *Report) {
+	backend, reason := secrets.ActiveBackend()
+	if err := secrets.KeyringAvailable(); err == nil {
+		report.add("keyring", StatusPass, "system keyring is available", "")
+	} else if backend.Name() == config.SecretsBackendFile {
+		report.add("keyring", StatusWarn, fmt.Sprintf("system keyring is unavailable: %v", err), "Keys are kept in the encrypted file instead")
+	} else {
+		report.add("keyring", StatusFail, fmt.Sprintf("system keyring is unavailable: %v", err), "Start a Secret Service provider (gnome-keyring, KWallet), set secrets_backend to file or use environment variables for keys")
+	}
+
+	file, ok := backend.(*secrets.FileBackend)
+	if !ok {
+		report.add("secrets backend", StatusPass, fmt.Sprintf("%s (%s)", backend.Name(), reason), "")
+		return
+	}
+
+	message := fmt.Sprintf("file %s (%s)", file.Path(), reason)
+	switch {
+	case !file.Exists():
+		report.add("secrets backend", StatusPass, message+", no secrets stored yet", "")
+	case file.Unlocked() || secrets.PassphraseAvailable():
+		if err := file.Unlock(); err != nil {
+			report.add("secrets backend", StatusFail, message+": "+err.Error(), "Check "+secrets.PassphraseEnvVar)
+			return
+		}
+		report.add("secrets backend", StatusPass, message+", unlocked", "")
+	default:
+		report.add("secrets backend", StatusWarn, message+", locked", fmt.Sprintf("Set %s or %s so keys can be read without a prompt", secrets.PassphraseEnvVar, secrets.PassphraseFDEnvVar))
+	}
+}
+
+func checkCLIs(report *Report) {
+	for _, cli := range []struct{ name, url string }{
+		{"gh", "https://cli.github.com/"},
+		{"glab", "https://glab.readthedocs.io/"},
+	} {
+		if path, err := exec.LookPath(cli.name); err == nil {
+			report.add(cli.name, StatusPass, path, "")
+		} else {
+			report.add(cli.name, StatusWarn, cli.name+" is not installed", "Needed for gs pr; install it from "+cli.url)
+		}
+	}
+}
+
+func checkAgentKeys(report *Report, cfg *config.Config) {
+	keys := secrets.NewKeyStore()
+	opencode, _ := secrets.LoadOpenCodeAuth()
+
+	for _, agent := range cfg.Agents {
+		name := "key: " + agent.Name
+		if !catalog.RequiresAPIKey(string(agent.Provider)) {
+			report.add(name, StatusPass, "no API key required", "")
+			continue
+		}
+
+		envVar := keys.EnvVar(agent.Provider)
+		resolved := keys.Resolve(agent)
+		switch source := resolved.Source; source {
+		case secrets.SourceEnv:
+			report.add(name, StatusPass, "env "+strings.TrimPrefix(resolved.Ref, "env:"), "")
+		case secrets.SourceAgent, secrets.SourceProvider, secrets.SourceOAuth:
+			report.add(name, StatusPass, keys.Backend().Name()+" ("+string(source)+")", "")
+		case secrets.SourceCommand, secrets.SourceFile:
+			report.add(name, StatusPass, resolved.Ref, "")
+		case secrets.SourceOpenCode:
+			if opencode.IsTokenExpired(string(agent.Provider)) {
+				report.add(name, StatusPass, "OpenCode auth (token refreshed)", "")
+				continue
+			}
+			report.add(name, StatusPass, "OpenCode auth", "")
+		default:
+			if resolved.Err != nil && agent.KeySource != "" {
+				report.add(name, StatusFail, fmt.Sprintf("%s: %v", resolved.Ref, resolved.Err), fmt.Sprintf("Fix key_source with 'gs config set agents.%s.key_source <source>'", agent.Name))
+				continue
+			}
+			if resolved.Err != nil {
+				report.add(name, StatusFail, fmt.Sprintf("%s: %v", resolved.Ref, resolved.Err), fmt.Sprintf("Unlock the secrets backend (set %s for the encrypted file) or export %s", secrets.PassphraseEnvVar, envVar))
+				continue
+			}
+			if opencode.CanRefresh(string(agent.Provider)) && opencode.IsTokenExpired(string(agent.Provider)) {
+				report.add(name, StatusFail, "OpenCode token expired and could not be refreshed", "Log in again with OpenCode")
+				continue
+			}
+			report.add(name, StatusFail, "no API key in env, keyring or OpenCode auth", fmt.Sprintf("Run 'gs agent set-key %s' or export %s", agent.Name, envVar))
+		}
+	}
+}
+
+func checkReachability(ctx context.Context, report *Report, cfg *config.Config) {
+	var enabled []config.AgentProfile
+	for _, agent := range cfg.Agents {
+		if agent.Enabled {
+			enabled = append(enabled, agent)
+		}
+	}
+
+	checks := make([]Check, len(enabled))
+	var wg sync.WaitGroup
+	for i, agent := range enabled {
+		wg.Add(1)
+		go func(i int, agent config.AgentProfile) {
+			defer wg.Done()
+			checks[i] = reachability(ctx, cfg, agent)
+		}(i, agent)
+	}
+	wg.Wait()
+
+	report.Checks = append(report.Checks, checks...)
+}
+
+func reachability(ctx context.Context, cfg *config.Config, agent config.AgentProfile) Check {
+	check := Check{Name: "reach: " + agent.Name}
+
+	pConfig, _ := catalog.GetProviderConfig(string(agent.Provider))
+	baseURL := agent.BaseURL
+	if baseURL == "" {
+		baseURL = pConfig.BaseURL
+	}
+	if baseURL == "" {
+		check.Status = StatusWarn
+		check.Message = "no base URL to check"
+		return check
+	}
+
+	client, err := agents.NewHTTPClient(agents.NewTransportOptions(cfg.Global, agent, pConfig.Headers))
+	if err != nil {
+		check.Status = StatusFail
+		check.Message = err.Error()
+		check.Hint = "Fix the proxy or TLS settings for this agent"
+		return check
+	}
+	client.Timeout = reachabilityTimeout
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
+	if err != nil {
+		check.Status = StatusFail
+		check.Message = fmt.Sprintf("invalid base URL %q", baseURL)
+		check.Hint = "Correct base_url for this agent or provider"
+		return check
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		check.Status = StatusFail
+		check.Message = fmt.Sprintf("%s is unreachable: %v", baseURL, err)
+		check.Hint = "Check your network, proxy settings and base_url"
+		if agent.Provider == config.ProviderOllama {
+			check.Hint = "Start Ollama with 'ollama serve'"
+		}
+		return check
+	}
+	resp.Body.Close()
+
+	check.Status = StatusPass
+	check.Message = fmt.Sprintf("%s responded in %s", baseURL, time.Since(start).Round(time.Millisecond))
+	return check
+}
+
+func checkCatalog(report *Report) {
+	cache, err := catalog.LoadCache()
+	if err != nil {
+		report.add("model catalog", StatusWarn, fmt.Sprintf("cannot read catalog cache: %v", err), "Run 'gs models refresh' to rebuild it")
+		return
+	}
+	if len(cache.Providers) == 0 {
+		report.add("model catalog", StatusWarn, "no provider model lists have been fetched", "Run 'gs models refresh' to fetch current models")
+		return
+	}
+
+	var stale []string
+	for provider, entry := range cache.Providers {
+		if time.Since(entry.FetchedAt) > catalog.DefaultCacheTTL {
+			stale = append(stale, provider)
+		}
+	}
+	if len(stale) > 0 {
+		sort.Strings(stale)
+		report.add("model catalog", StatusWarn, fmt.Sprintf("stale model lists for %s", strings.Join(stale, ", ")), "Run 'gs models refresh' to update them")
+		return
+	}
+	report.add("model catalog", StatusPass, fmt.Sprintf("%d providers refreshed within %s", len(cache.Providers), catalog.DefaultCacheTTL), "")
+}
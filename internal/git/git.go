@@ -7,6 +7,8 @@ import (
 	"io"
 	"os/exec"
 	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/logging"
 )
 
 func StageFiles(files []string) error {
@@ -15,7 +17,7 @@ func StageFiles(files []string) error {
 	}
 
 	args := append([]string{"add"}, files...)
-	cmd := exec.Command("git", args...)
+	cmd := command(args...)
 	cmd.Stdout = io.Discard
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
@@ -29,7 +31,7 @@ func StageFiles(files []string) error {
 
 func GetStagedDiff() (string, error) {
 	var diffOutput bytes.Buffer
-	cmd := exec.Command("git", "diff", "--staged")
+	cmd := command("diff", "--staged")
 
 	cmd.Stdout = &diffOutput
 	cmd.Stderr = &diffOutput
@@ -40,10 +42,31 @@ func GetStagedDiff() (string, error) {
 	return diffOutput.String(), nil
 }
 
+func GetStagedFiles() ([]string, error) {
+	var output bytes.Buffer
+	cmd := command("diff", "--staged", "--name-status")
+
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git diff --staged --name-status failed: %w\n%s", err, output.String())
+	}
+
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		files = append(files, strings.Join(strings.Fields(line), " "))
+	}
+	return files, nil
+}
+
 func Commit(message string) error {
 	var output bytes.Buffer
 
-	cmd := exec.Command("git", "commit", "-F", "-")
+	cmd := command("commit", "-F", "-")
 
 	cmd.Stdin = strings.NewReader(message)
 
@@ -58,7 +81,7 @@ func Commit(message string) error {
 }
 
 func IsInsideWorkTree() error {
-	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+	cmd := command("rev-parse", "--is-inside-work-tree")
 
 	if err := cmd.Run(); err != nil {
 		return errors.New("not inside a git repository")
@@ -69,7 +92,7 @@ func IsInsideWorkTree() error {
 
 func Push(branch string) error {
 	var output bytes.Buffer
-	cmd := exec.Command("git", "push", "origin", branch)
+	cmd := command("push", "origin", branch)
 	cmd.Stdout = &output
 	cmd.Stderr = &output
 	if err := cmd.Run(); err != nil {
@@ -79,10 +102,36 @@ func Push(branch string) error {
 }
 
 func GetCurrentBranch() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd := command("rev-parse", "--abbrev-ref", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get current branch: %v", err)
 	}
 	return strings.TrimSpace(string(output)), nil
 }
+
+func command(args ...string) *exec.Cmd {
+	logging.Debug("running git", "args", strings.Join(args, " "))
+	return exec.Command("git", args...)
+}
+
+func UnstageFiles(files []string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	args := []string{"reset", "-q", "--"}
+	for _, file := range files {
+		args = append(args, ":(top)"+file)
+	}
+	cmd := command(args...)
+	cmd.Stdout = io.Discard
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git reset failed: %s", stderr.String())
+	}
+
+	return nil
+}
@@ -2,11 +2,10 @@ package git
 
 import (
 	"fmt"
-	"os/exec"
 )
 
 func GetCommitLog(branch string, limit int) (string, error) {
-	cmd := exec.Command("git", "log", "--oneline", "-n", fmt.Sprintf("%d", limit), branch)
+	cmd := command("log", "--oneline", "-n", fmt.Sprintf("%d", limit), branch)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
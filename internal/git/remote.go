@@ -6,7 +6,7 @@ import (
 )
 
 func GetRemoteURL() (string, error) {
-	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd := command("remote", "get-url", "origin")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
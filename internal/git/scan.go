@@ -0,0 +1,337 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	AllowlistFileName = ".gitscribe-allowlist"
+	InlineAllowMarker = "gitscribe:allow"
+	minGenericEntropy = 3.5
+)
+
+type SecretFinding struct {
+	File  string
+	Line  int
+	Rule  string
+	Match string
+	Added bool
+}
+
+type secretRule struct {
+	name    string
+	pattern *regexp.Regexp
+	group   int
+	entropy bool
+}
+
+var secretRules = []secretRule{
+	{name: "private-key", pattern: regexp.MustCompile(`-----BEGIN [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----`)},
+	{name: "aws-access-key-id", pattern: regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA)[0-9A-Z]{16}\b`)},
+	{name: "aws-secret-access-key", pattern: regexp.MustCompile(`(?i)aws.{0,20}?(?:secret|private).{0,20}?['"]?\s*[:=]\s*['"]?([A-Za-z0-9/+=]{40})\b`), group: 1},
+	{name: "anthropic-api-key", pattern: regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{20,}`)},
+	{name: "openai-api-key", pattern: regexp.MustCompile(`\bsk-(?:proj-|svcacct-|admin-)?[A-Za-z0-9_-]{20,}`)},
+	{name: "groq-api-key", pattern: regexp.MustCompile(`\bgsk_[A-Za-z0-9]{20,}`)},
+	{name: "github-token", pattern: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{50,})`)},
+	{name: "gitlab-token", pattern: regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}`)},
+	{name: "slack-token", pattern: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{name: "stripe-key", pattern: regexp.MustCompile(`\b(?:sk|rk)_live_[A-Za-z0-9]{20,}`)},
+	{name: "google-api-key", pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{name: "jwt", pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{name: "url-credentials", pattern: regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]+:([^/\s:@]{3,})@`), group: 1},
+	{name: "generic-secret", pattern: regexp.MustCompile(`(?i)(?:api[_-]?key|secret|token|passw(?:or)?d|credential|auth[_-]?key|private[_-]?key)[A-Za-z0-9_.-]*['"]?\s*(?::=|[:=]|=>)\s*['"]?([^\s'"` + "`" + `,;]{12,})`), group: 1, entropy: true},
+}
+
+var sensitiveFileNames = []string{".env", ".env.*", "*.env", ".netrc", ".npmrc", ".pypirc", "credentials", "id_rsa", "id_dsa", "id_ecdsa", "id_ed25519", "*.pem", "*.key", "*.p12", "*.pfx"}
+
+var (
+	diffFileHeader = regexp.MustCompile(`^\+\+\+ (?:b/)?(.+)$`)
+	diffHunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+	envAssignment  = regexp.MustCompile(`^\s*(?:export\s+)?([A-Za-z_][A-Za-z0-9_.-]*)\s*[:=]\s*['"]?([^\s'"#]{6,})`)
+	secretKeyName  = regexp.MustCompile(`(?i)key|secret|token|passw(?:or)?d|pwd|credential|auth`)
+	privateKeyEnd  = regexp.MustCompile(`-----END [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----`)
+)
+
+type Allowlist struct {
+	paths  []string
+	rules  map[string]bool
+	values []*regexp.Regexp
+}
+
+func LoadAllowlist(repoRoot string) (*Allowlist, error) {
+	allow := &Allowlist{rules: make(map[string]bool)}
+	if repoRoot == "" {
+		return allow, nil
+	}
+
+	file, err := os.Open(filepath.Join(repoRoot, AllowlistFileName))
+	if os.IsNotExist(err) {
+		return allow, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", AllowlistFileName, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "path:"):
+			allow.paths = append(allow.paths, strings.TrimSpace(strings.TrimPrefix(line, "path:")))
+		case strings.HasPrefix(line, "rule:"):
+			allow.rules[strings.TrimSpace(strings.TrimPrefix(line, "rule:"))] = true
+		default:
+			re, err := regexp.Compile(line)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid pattern: %w", AllowlistFileName, lineNo, err)
+			}
+			allow.values = append(allow.values, re)
+		}
+	}
+	return allow, scanner.Err()
+}
+
+func (a *Allowlist) allowsPath(file string) bool {
+	if a == nil {
+		return false
+	}
+	for _, pattern := range a.paths {
+		if matchPathPattern(pattern, file) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Allowlist) allows(f SecretFinding) bool {
+	if a == nil {
+		return false
+	}
+	if a.rules[f.Rule] {
+		return true
+	}
+	for _, re := range a.values {
+		if re.MatchString(f.Match) {
+			return true
+		}
+	}
+	return false
+}
+
+func ScanDiff(diff string, allow *Allowlist) []SecretFinding {
+	var findings []SecretFinding
+	var file, oldFile string
+	var oldLine, newLine int
+	skipFile, sensitive, inKey := false, false, false
+
+	for _, raw := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(raw, "diff --git ") {
+			file, oldFile, skipFile, sensitive, inKey = "", "", false, false, false
+			continue
+		}
+		if file == "" {
+			if name, ok := strings.CutPrefix(raw, "--- "); ok {
+				oldFile = strings.TrimPrefix(name, "a/")
+				continue
+			}
+			if m := diffFileHeader.FindStringSubmatch(raw); m != nil {
+				file = m[1]
+				if file == "/dev/null" {
+					file = oldFile
+				}
+				skipFile = allow.allowsPath(file)
+				sensitive = isSensitiveFile(file)
+				continue
+			}
+		}
+		if m := diffHunkHeader.FindStringSubmatch(raw); m != nil {
+			oldLine, _ = strconv.Atoi(m[1])
+			newLine, _ = strconv.Atoi(m[2])
+			continue
+		}
+		if file == "" || skipFile || raw == "" {
+			continue
+		}
+
+		var current int
+		added := false
+		switch raw[0] {
+		case '+':
+			current, added = newLine, true
+			newLine++
+		case '-':
+			current = oldLine
+			oldLine++
+		case ' ':
+			current = newLine
+			oldLine++
+			newLine++
+		default:
+			continue
+		}
+
+		text := raw[1:]
+		if strings.Contains(text, InlineAllowMarker) {
+			continue
+		}
+
+		if inKey {
+			if privateKeyEnd.MatchString(text) {
+				inKey = false
+			} else if trimmed := strings.TrimSpace(text); trimmed != "" {
+				findings = appendFinding(findings, allow, SecretFinding{File: file, Line: current, Rule: "private-key", Match: trimmed, Added: added})
+			}
+			continue
+		}
+
+		var claimed []string
+		for _, rule := range secretRules {
+			for _, m := range rule.pattern.FindAllStringSubmatch(text, -1) {
+				value := m[rule.group]
+				if rule.entropy && (ShannonEntropy(value) < minGenericEntropy || looksLikePlaceholder(value)) {
+					continue
+				}
+				if containsAny(claimed, value) {
+					continue
+				}
+				claimed = append(claimed, value)
+				findings = appendFinding(findings, allow, SecretFinding{File: file, Line: current, Rule: rule.name, Match: value, Added: added})
+			}
+			if rule.name == "private-key" && rule.pattern.MatchString(text) && !privateKeyEnd.MatchString(text) {
+				inKey = true
+			}
+		}
+
+		if sensitive && len(claimed) == 0 {
+			if m := envAssignment.FindStringSubmatch(text); m != nil && !looksLikePlaceholder(m[2]) && (secretKeyName.MatchString(m[1]) || ShannonEntropy(m[2]) >= minGenericEntropy) {
+				findings = appendFinding(findings, allow, SecretFinding{File: file, Line: current, Rule: "sensitive-file", Match: m[2], Added: added})
+			}
+		}
+	}
+	return findings
+}
+
+func appendFinding(findings []SecretFinding, allow *Allowlist, f SecretFinding) []SecretFinding {
+	if allow.allows(f) {
+		return findings
+	}
+	for _, existing := range findings {
+		if existing.File == f.File && existing.Line == f.Line && strings.Contains(existing.Match, f.Match) {
+			return findings
+		}
+	}
+	return append(findings, f)
+}
+
+func containsAny(values []string, value string) bool {
+	for _, v := range values {
+		if strings.Contains(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func RedactSecrets(diff string, findings []SecretFinding) string {
+	if len(findings) == 0 {
+		return diff
+	}
+	matches := make([]SecretFinding, len(findings))
+	copy(matches, findings)
+	sort.SliceStable(matches, func(i, j int) bool {
+		return len(matches[i].Match) > len(matches[j].Match)
+	})
+	for _, f := range matches {
+		diff = strings.ReplaceAll(diff, f.Match, fmt.Sprintf("[REDACTED:%s]", f.Rule))
+	}
+	return diff
+}
+
+func AddedFindings(findings []SecretFinding) []SecretFinding {
+	var added []SecretFinding
+	for _, f := range findings {
+		if f.Added {
+			added = append(added, f)
+		}
+	}
+	return added
+}
+
+func FindingFiles(findings []SecretFinding) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, f := range findings {
+		if !seen[f.File] {
+			seen[f.File] = true
+			files = append(files, f.File)
+		}
+	}
+	return files
+}
+
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	total := float64(len([]rune(s)))
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / total
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+func looksLikePlaceholder(value string) bool {
+	lower := strings.ToLower(value)
+	for _, marker := range []string{"xxxx", "example", "placeholder", "changeme", "your_", "your-", "<", "${", "{{", "process.env", "os.getenv", "redacted", "dummy"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSensitiveFile(file string) bool {
+	base := path.Base(file)
+	for _, pattern := range sensitiveFileNames {
+		if ok, _ := path.Match(pattern, base); ok {
+			return !strings.HasSuffix(base, ".example") && !strings.HasSuffix(base, ".sample") && !strings.HasSuffix(base, ".template")
+		}
+	}
+	return false
+}
+
+func matchPathPattern(pattern, file string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return file == prefix || strings.HasPrefix(file, prefix+"/")
+	}
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(file, pattern)
+	}
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(file))
+		return ok
+	}
+	ok, _ := path.Match(pattern, file)
+	return ok
+}
@@ -0,0 +1,134 @@
+package logging
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const maxTraceBodyBytes = 64 << 10
+
+var sensitiveHeaders = map[string]bool{
+	"authorization":        true,
+	"proxy-authorization":  true,
+	"x-api-key":            true,
+	"api-key":              true,
+	"x-goog-api-key":       true,
+	"x-amz-security-token": true,
+	"cookie":               true,
+	"set-cookie":           true,
+}
+
+var sensitiveHeadersMu sync.RWMutex
+
+func RegisterSensitiveHeader(name string) {
+	if name == "" {
+		return
+	}
+	sensitiveHeadersMu.Lock()
+	defer sensitiveHeadersMu.Unlock()
+	sensitiveHeaders[strings.ToLower(name)] = true
+}
+
+func isSensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	sensitiveHeadersMu.RLock()
+	defer sensitiveHeadersMu.RUnlock()
+	return sensitiveHeaders[lower] || secretAttrPattern.MatchString(lower)
+}
+
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if _, ok := base.(*loggingTransport); ok {
+		return base
+	}
+	return &loggingTransport{base: base}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	url := Redact(req.URL.Redacted())
+	if traceHTTP {
+		body, err := readRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+		Debug("http request", "method", req.Method, "url", url, "headers", formatHeaders(req.Header), "body", traceBody(body))
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start)
+	if err != nil {
+		Warn("http request failed", "method", req.Method, "url", url, "duration", elapsed, "error", err)
+		return nil, err
+	}
+
+	Debug("http response", "method", req.Method, "url", url, "status", resp.StatusCode, "duration", elapsed)
+	if traceHTTP {
+		body, err := readResponseBody(resp)
+		if err != nil {
+			return nil, err
+		}
+		Debug("http response body", "url", url, "headers", formatHeaders(resp.Header), "body", traceBody(body))
+	}
+	return resp, nil
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil {
+		return nil, nil
+	}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return []byte("[streaming body not captured]"), nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func traceBody(body []byte) string {
+	if len(body) > maxTraceBodyBytes {
+		return Redact(string(body[:maxTraceBodyBytes])) + "...[truncated]"
+	}
+	return Redact(string(body))
+}
+
+func formatHeaders(header http.Header) string {
+	var parts []string
+	for name, values := range header {
+		value := Redact(strings.Join(values, ", "))
+		if isSensitiveHeader(name) {
+			value = "[REDACTED]"
+		}
+		parts = append(parts, name+": "+value)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "; ")
+}
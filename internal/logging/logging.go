@@ -0,0 +1,169 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+const (
+	logDirName  = "logs"
+	logFileName = "gs.log"
+)
+
+type Options struct {
+	Level     string
+	Verbose   bool
+	Debug     bool
+	TraceHTTP bool
+}
+
+var (
+	logger    = slog.New(slog.NewTextHandler(io.Discard, nil))
+	logFile   *RotatingFile
+	traceHTTP bool
+)
+
+var secretAttrPattern = regexp.MustCompile(`(?i)(api[_-]?key|token|secret|password|authorization|credential)`)
+
+var secretValuePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`),
+	regexp.MustCompile(`\b(sk-ant-|sk-or-|sk-proj-|sk-|gsk_|xai-|AIza|ghp_|github_pat_|glpat-)[A-Za-z0-9_-]{8,}`),
+	regexp.MustCompile(`(?i)("(?:api_?key|access_token|refresh_token|id_token|client_secret|password|token|key)"\s*:\s*")[^"]*(")`),
+	regexp.MustCompile(`(?i)(AWS4-HMAC-SHA256 Credential=)[^,\s]+`),
+}
+
+func GetLogPath() (string, error) {
+	dir, err := config.EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, logDirName, logFileName), nil
+}
+
+func Setup(opts Options) error {
+	level := ParseLevel(opts.Level)
+	if opts.Verbose && level > slog.LevelInfo {
+		level = slog.LevelInfo
+	}
+	if opts.Debug || opts.TraceHTTP {
+		level = slog.LevelDebug
+	}
+	traceHTTP = opts.TraceHTTP
+
+	var writers []io.Writer
+	path, err := GetLogPath()
+	if err == nil {
+		logFile, err = OpenRotatingFile(path, defaultMaxLogSize, defaultMaxLogBackups)
+	}
+	if err == nil {
+		writers = append(writers, logFile)
+	}
+	if opts.Verbose || opts.Debug {
+		writers = append(writers, os.Stderr)
+	}
+	if len(writers) == 0 {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	handler := slog.NewTextHandler(io.MultiWriter(writers...), &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	})
+	logger = slog.New(handler)
+	slog.SetDefault(logger)
+
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	return nil
+}
+
+func Close() error {
+	if logFile == nil {
+		return nil
+	}
+	return logFile.Close()
+}
+
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug", "trace":
+		return slog.LevelDebug
+	case "info":
+		return slog.LevelInfo
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func Logger() *slog.Logger {
+	return logger
+}
+
+func TraceHTTP() bool {
+	return traceHTTP
+}
+
+func Enabled(level slog.Level) bool {
+	return logger.Enabled(context.Background(), level)
+}
+
+func Debug(msg string, args ...any) {
+	logger.Debug(msg, args...)
+}
+
+func Info(msg string, args ...any) {
+	logger.Info(msg, args...)
+}
+
+func Warn(msg string, args ...any) {
+	logger.Warn(msg, args...)
+}
+
+func Error(msg string, args ...any) {
+	logger.Error(msg, args...)
+}
+
+func Redact(s string) string {
+	for _, re := range secretValuePatterns {
+		switch re.NumSubexp() {
+		case 2:
+			s = re.ReplaceAllString(s, "${1}[REDACTED]${2}")
+		default:
+			s = re.ReplaceAllString(s, "${1}[REDACTED]")
+		}
+	}
+	return s
+}
+
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey || a.Key == slog.LevelKey {
+		return a
+	}
+	if secretAttrPattern.MatchString(a.Key) && !strings.HasSuffix(a.Key, "_tokens") {
+		if a.Value.Kind() == slog.KindString && a.Value.String() != "" {
+			return slog.String(a.Key, "[REDACTED]")
+		}
+	}
+	if a.Value.Kind() == slog.KindString {
+		return slog.String(a.Key, Redact(a.Value.String()))
+	}
+	if a.Value.Kind() == slog.KindAny {
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, Redact(err.Error()))
+		}
+	}
+	return a
+}
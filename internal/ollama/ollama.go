@@ -0,0 +1,162 @@
+package ollama
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+)
+
+const DefaultHost = "http://localhost:11434"
+
+type Model struct {
+	Name       string    `json:"name"`
+	Model      string    `json:"model"`
+	Size       int64     `json:"size"`
+	ModifiedAt time.Time `json:"modified_at"`
+}
+
+type Client struct {
+	host string
+	http *http.Client
+}
+
+func NewClient(host string) *Client {
+	if host == "" {
+		host = DefaultHost
+	}
+	return &Client{
+		host: strings.TrimSuffix(host, "/"),
+		http: &http.Client{Transport: logging.Transport(nil)},
+	}
+}
+
+func HostFromBaseURL(baseURL string) string {
+	if baseURL == "" {
+		return DefaultHost
+	}
+	host := strings.TrimSuffix(baseURL, "/")
+	host = strings.TrimSuffix(host, "/v1")
+	return host
+}
+
+func (c *Client) Host() string {
+	return c.host
+}
+
+func (c *Client) Health(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.host+"/api/version", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("ollama is not reachable at %s: %w", c.host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ollama health check failed at %s (%d)", c.host, resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *Client) ListModels(ctx context.Context) ([]Model, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.host+"/api/tags", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ollama is not reachable at %s: %w", c.host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("ollama api error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var tags struct {
+		Models []Model `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("failed to parse ollama models: %w", err)
+	}
+	return tags.Models, nil
+}
+
+func (c *Client) HasModel(ctx context.Context, name string) (bool, error) {
+	models, err := c.ListModels(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, m := range models {
+		if MatchesModel(m.Name, name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *Client) Pull(ctx context.Context, name string, progress func(status string)) error {
+	body := strings.NewReader(fmt.Sprintf(`{"model":%q,"stream":true}`, name))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.host+"/api/pull", body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("content-type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("pull request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("ollama pull error (%d): %s", resp.StatusCode, string(data))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var event struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		if event.Error != "" {
+			return fmt.Errorf("ollama pull failed: %s", event.Error)
+		}
+		if progress != nil && event.Status != "" {
+			progress(event.Status)
+		}
+	}
+	return scanner.Err()
+}
+
+func MatchesModel(installed, wanted string) bool {
+	if installed == wanted {
+		return true
+	}
+	if !strings.Contains(wanted, ":") {
+		return installed == wanted+":latest"
+	}
+	return false
+}
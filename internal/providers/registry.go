@@ -0,0 +1,49 @@
+package providers
+
+import (
+	"github.com/albuquerquesz/gitscribe/internal/auth"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+const defaultCallbackPath = "/callback"
+
+type OAuthProvider struct {
+	client config.OAuthClient
+}
+
+func NewOAuthProvider(client config.OAuthClient) *OAuthProvider {
+	return &OAuthProvider{client: client}
+}
+
+func (p *OAuthProvider) Name() string {
+	return p.client.Name
+}
+
+func (p *OAuthProvider) Endpoint() auth.Endpoint {
+	callbackPath := p.client.CallbackPath
+	if callbackPath == "" {
+		callbackPath = defaultCallbackPath
+	}
+	return auth.Endpoint{
+		AuthURL:      p.client.AuthURL,
+		TokenURL:     p.client.TokenURL,
+		ClientID:     p.client.ClientID,
+		Scopes:       p.client.Scopes,
+		CallbackPath: callbackPath,
+		Port:         p.client.Port,
+	}
+}
+
+func Get(name string) (auth.Provider, bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, false
+	}
+	client, ok := cfg.GetOAuthClient(name)
+	if !ok || client.ClientID == "" || client.AuthURL == "" || client.TokenURL == "" {
+		return nil, false
+	}
+	return NewOAuthProvider(*client), true
+}
+
+var _ auth.Provider = (*OAuthProvider)(nil)
@@ -3,11 +3,27 @@ package router
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/albuquerquesz/gitscribe/internal/agents"
 	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+	"github.com/albuquerquesz/gitscribe/internal/usage"
 )
 
+type Request struct {
+	Agent    string
+	Messages []agents.Message
+	Options  agents.RequestOptions
+}
+
+type Result struct {
+	Agent    string
+	Response *agents.Response
+	Err      error
+}
+
 type Router struct {
 	config  *config.Config
 	factory *agents.Factory
@@ -16,7 +32,7 @@ type Router struct {
 func NewRouter(cfg *config.Config) *Router {
 	return &Router{
 		config:  cfg,
-		factory: agents.NewFactory(),
+		factory: agents.NewFactory(cfg),
 	}
 }
 
@@ -26,10 +42,46 @@ func (r *Router) RouteRequest(ctx context.Context, agentName string, messages []
 		return nil, fmt.Errorf("agent profile not found: %w", err)
 	}
 
+	if r.config.Global.BudgetAction == config.BudgetActionBlock {
+		if status, err := usage.CheckBudget(*profile); err == nil && status.Exceeded() {
+			logging.Warn("request blocked by budget", "agent", agentName, "spent", status.Spent, "budget", status.Budget)
+			return nil, fmt.Errorf("monthly budget for agent %s exceeded ($%.2f of $%.2f spent)", agentName, status.Spent, status.Budget)
+		}
+	}
+
 	client, err := r.factory.CreateClient(*profile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client for agent %s: %w", agentName, err)
 	}
+	defer client.Close()
+
+	logging.Debug("routing request", "agent", agentName, "provider", profile.Provider, "model", profile.Model, "messages", len(messages))
+	start := time.Now()
+	resp, err := client.SendMessage(ctx, messages, options)
+	if err != nil {
+		logging.Error("request failed", "agent", agentName, "model", profile.Model, "duration", time.Since(start), "error", err)
+		return nil, err
+	}
+	logging.Info("request completed", "agent", agentName, "model", resp.Model, "duration", time.Since(start),
+		"prompt_tokens", resp.Usage.PromptTokens, "completion_tokens", resp.Usage.CompletionTokens, "finish_reason", resp.FinishReason)
+
+	_ = usage.Record(usage.NewEntry(*profile, resp.Usage.PromptTokens, resp.Usage.CompletionTokens, resp.Usage.TotalTokens, time.Since(start)))
+	return resp, nil
+}
+
+func (r *Router) RouteAll(ctx context.Context, requests []Request) []Result {
+	results := make([]Result, len(requests))
+
+	var wg sync.WaitGroup
+	for i, req := range requests {
+		wg.Add(1)
+		go func(i int, req Request) {
+			defer wg.Done()
+			resp, err := r.RouteRequest(ctx, req.Agent, req.Messages, req.Options)
+			results[i] = Result{Agent: req.Agent, Response: resp, Err: err}
+		}(i, req)
+	}
+	wg.Wait()
 
-	return client.SendMessage(ctx, messages, options)
+	return results
 }
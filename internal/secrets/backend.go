@@ -0,0 +1,121 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	BackendEnvVar = "GS_SECRETS_BACKEND"
+	probeKey      = "gitscribe-probe"
+)
+
+var ErrNotFound = errors.New("secret not found")
+
+type Backend interface {
+	Name() string
+	Get(service, key string) (string, error)
+	Set(service, key, value string) error
+	Delete(service, key string) error
+}
+
+type keyringBackend struct{}
+
+func (keyringBackend) Name() string {
+	return config.SecretsBackendKeyring
+}
+
+func (keyringBackend) Get(service, key string) (string, error) {
+	value, err := keyring.Get(service, key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return value, err
+}
+
+func (keyringBackend) Set(service, key, value string) error {
+	return keyring.Set(service, key, value)
+}
+
+func (keyringBackend) Delete(service, key string) error {
+	err := keyring.Delete(service, key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+var (
+	backendMu     sync.Mutex
+	activeBackend Backend
+	backendReason string
+)
+
+func KeyringAvailable() error {
+	_, err := keyring.Get(ServiceName, probeKey)
+	if err == nil || errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func BackendByName(name string) (Backend, error) {
+	switch strings.ToLower(name) {
+	case config.SecretsBackendKeyring:
+		return keyringBackend{}, nil
+	case config.SecretsBackendFile:
+		return DefaultFileBackend()
+	default:
+		return nil, fmt.Errorf("unknown secrets backend %q (use %s or %s)", name, config.SecretsBackendKeyring, config.SecretsBackendFile)
+	}
+}
+
+func ActiveBackend() (Backend, string) {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	if activeBackend == nil {
+		activeBackend, backendReason = selectBackend()
+		logging.Debug("secrets backend selected", "backend", activeBackend.Name(), "reason", backendReason)
+	}
+	return activeBackend, backendReason
+}
+
+func SetBackend(backend Backend, reason string) {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	activeBackend = backend
+	backendReason = reason
+}
+
+func selectBackend() (Backend, string) {
+	if name := os.Getenv(BackendEnvVar); name != "" && name != config.SecretsBackendAuto {
+		if backend, err := BackendByName(name); err == nil {
+			return backend, BackendEnvVar
+		}
+		logging.Warn("ignoring invalid secrets backend", "env", BackendEnvVar, "value", name)
+	}
+
+	if cfg, err := config.Load(); err == nil {
+		if name := cfg.Global.SecretsBackend; name != "" && name != config.SecretsBackendAuto {
+			if backend, err := BackendByName(name); err == nil {
+				return backend, "global.secrets_backend"
+			}
+		}
+	}
+
+	err := KeyringAvailable()
+	if err == nil {
+		return keyringBackend{}, "keyring available"
+	}
+	if backend, ferr := DefaultFileBackend(); ferr == nil {
+		return backend, fmt.Sprintf("keyring unavailable: %v", err)
+	}
+	return keyringBackend{}, "keyring unavailable and no config directory for the encrypted file"
+}
@@ -0,0 +1,454 @@
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	SecretsFileName     = "secrets.enc"
+	PassphraseEnvVar    = "GS_SECRETS_PASSPHRASE"
+	PassphraseFDEnvVar  = "GS_SECRETS_PASSPHRASE_FD"
+	secretsFileVersion  = 1
+	scryptN             = 1 << 15
+	scryptR             = 8
+	scryptP             = 1
+	secretsKeyLength    = 32
+	secretsSaltLength   = 16
+	minPassphraseLength = 8
+	maxScryptN          = 1 << 20
+	maxScryptR          = 32
+	maxScryptP          = 16
+	secretsLockTimeout  = 10 * time.Second
+	secretsLockStale    = time.Minute
+)
+
+var ErrLocked = errors.New("encrypted secrets file is locked")
+
+type PassphrasePrompt func(label string) (string, error)
+
+var (
+	passphrasePrompt PassphrasePrompt
+	fdPassphrase     string
+)
+
+func SetPassphrasePrompt(prompt PassphrasePrompt) {
+	passphrasePrompt = prompt
+}
+
+type secretsFile struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	N       int    `json:"n"`
+	R       int    `json:"r"`
+	P       int    `json:"p"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+type FileBackend struct {
+	mu      sync.Mutex
+	path    string
+	key     []byte
+	salt    []byte
+	params  [3]int
+	entries map[string]map[string]string
+}
+
+var (
+	defaultFileOnce    sync.Once
+	defaultFileBackend *FileBackend
+	defaultFileErr     error
+)
+
+func DefaultFileBackend() (*FileBackend, error) {
+	defaultFileOnce.Do(func() {
+		dir, err := config.EnsureConfigDir()
+		if err != nil {
+			defaultFileErr = err
+			return
+		}
+		defaultFileBackend = NewFileBackend(filepath.Join(dir, SecretsFileName))
+	})
+	return defaultFileBackend, defaultFileErr
+}
+
+func NewFileBackend(path string) *FileBackend {
+	return &FileBackend{path: path}
+}
+
+func (f *FileBackend) Name() string {
+	return config.SecretsBackendFile
+}
+
+func (f *FileBackend) Path() string {
+	return f.path
+}
+
+func (f *FileBackend) Exists() bool {
+	_, err := os.Stat(f.path)
+	return err == nil
+}
+
+func (f *FileBackend) Unlocked() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.entries != nil
+}
+
+func (f *FileBackend) Unlock() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.unlock()
+}
+
+func (f *FileBackend) unlock() error {
+	if f.entries != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		passphrase, err := readPassphrase(true)
+		if err != nil {
+			return err
+		}
+		return f.initialize(passphrase)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read secrets file: %w", err)
+	}
+	return f.load(data)
+}
+
+func (f *FileBackend) load(data []byte) error {
+	var file secretsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse secrets file: %w", err)
+	}
+	if file.Version != secretsFileVersion || file.KDF != "scrypt" {
+		return fmt.Errorf("unsupported secrets file format (version %d, kdf %q)", file.Version, file.KDF)
+	}
+	if err := checkScryptParams(file.N, file.R, file.P); err != nil {
+		return fmt.Errorf("invalid secrets file %s: %w", f.path, err)
+	}
+
+	key := f.key
+	if key == nil || !bytes.Equal(file.Salt, f.salt) || f.params != [3]int{file.N, file.R, file.P} {
+		passphrase, err := readPassphrase(false)
+		if err != nil {
+			return err
+		}
+		key, err = scrypt.Key([]byte(passphrase), file.Salt, file.N, file.R, file.P, secretsKeyLength)
+		if err != nil {
+			return fmt.Errorf("failed to derive key: %w", err)
+		}
+	}
+
+	plaintext, err := openSealed(key, file.Nonce, file.Data)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt %s: wrong passphrase or corrupted file", f.path)
+	}
+
+	entries := make(map[string]map[string]string)
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return fmt.Errorf("failed to parse decrypted secrets: %w", err)
+	}
+
+	f.key = key
+	f.salt = file.Salt
+	f.params = [3]int{file.N, file.R, file.P}
+	f.entries = entries
+	return nil
+}
+
+func checkScryptParams(n, r, p int) error {
+	if n < 2 || n > maxScryptN || n&(n-1) != 0 || r < 1 || r > maxScryptR || p < 1 || p > maxScryptP {
+		return fmt.Errorf("unsupported scrypt parameters (N=%d, r=%d, p=%d)", n, r, p)
+	}
+	return nil
+}
+
+func (f *FileBackend) initialize(passphrase string) error {
+	salt := make([]byte, secretsSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, secretsKeyLength)
+	if err != nil {
+		return fmt.Errorf("failed to derive key: %w", err)
+	}
+	f.key = key
+	f.salt = salt
+	f.params = [3]int{scryptN, scryptR, scryptP}
+	f.entries = make(map[string]map[string]string)
+	return nil
+}
+
+func (f *FileBackend) Get(service, key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.Exists() && f.entries == nil {
+		return "", ErrNotFound
+	}
+	if err := f.unlock(); err != nil {
+		return "", err
+	}
+	value, ok := f.entries[service][key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (f *FileBackend) Set(service, key, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.unlock(); err != nil {
+		return err
+	}
+	return f.update(func() error {
+		if f.entries[service] == nil {
+			f.entries[service] = make(map[string]string)
+		}
+		f.entries[service][key] = value
+		return nil
+	})
+}
+
+func (f *FileBackend) Delete(service, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.Exists() && f.entries == nil {
+		return ErrNotFound
+	}
+	if err := f.unlock(); err != nil {
+		return err
+	}
+	return f.update(func() error {
+		if _, ok := f.entries[service][key]; !ok {
+			return ErrNotFound
+		}
+		delete(f.entries[service], key)
+		if len(f.entries[service]) == 0 {
+			delete(f.entries, service)
+		}
+		return nil
+	})
+}
+
+func (f *FileBackend) Keys(service string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.Exists() && f.entries == nil {
+		return nil, nil
+	}
+	if err := f.unlock(); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(f.entries[service]))
+	for key := range f.entries[service] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (f *FileBackend) update(modify func() error) error {
+	release, err := lockFile(f.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	data, err := os.ReadFile(f.path)
+	if err == nil {
+		if err := f.load(data); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	if err := modify(); err != nil {
+		return err
+	}
+	return f.save()
+}
+
+func lockFile(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+	deadline := time.Now().Add(secretsLockTimeout)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			fmt.Fprintf(file, "%d\n", os.Getpid())
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock secrets file: %w", err)
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > secretsLockStale {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for %s, remove it if no other gs command is running", path)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func (f *FileBackend) save() error {
+	plaintext, err := json.Marshal(f.entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode secrets: %w", err)
+	}
+	nonce, sealed, err := seal(f.key, plaintext)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(secretsFile{
+		Version: secretsFileVersion,
+		KDF:     "scrypt",
+		N:       f.params[0],
+		R:       f.params[1],
+		P:       f.params[2],
+		Salt:    f.salt,
+		Nonce:   nonce,
+		Data:    sealed,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode secrets file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	return nil
+}
+
+func seal(key, plaintext []byte) ([]byte, []byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return nonce, gcm.Seal(nil, nonce, plaintext, nil), nil
+}
+
+func openSealed(key, nonce, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length")
+	}
+	return gcm.Open(nil, nonce, sealed, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func PassphraseAvailable() bool {
+	return fdPassphrase != "" || os.Getenv(PassphraseEnvVar) != "" || os.Getenv(PassphraseFDEnvVar) != ""
+}
+
+func readPassphrase(create bool) (string, error) {
+	if passphrase := os.Getenv(PassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+
+	if fdPassphrase != "" {
+		return fdPassphrase, nil
+	}
+
+	if fd := os.Getenv(PassphraseFDEnvVar); fd != "" {
+		return readPassphraseFD(fd)
+	}
+
+	if passphrasePrompt == nil {
+		return "", fmt.Errorf("%w: set %s or %s to unlock it", ErrLocked, PassphraseEnvVar, PassphraseFDEnvVar)
+	}
+
+	if !create {
+		return passphrasePrompt("Passphrase for encrypted secrets")
+	}
+
+	passphrase, err := passphrasePrompt("New passphrase for encrypted secrets")
+	if err != nil {
+		return "", err
+	}
+	if len(passphrase) < minPassphraseLength {
+		return "", fmt.Errorf("passphrase must be at least %d characters", minPassphraseLength)
+	}
+	confirm, err := passphrasePrompt("Confirm passphrase")
+	if err != nil {
+		return "", err
+	}
+	if confirm != passphrase {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+func readPassphraseFD(value string) (string, error) {
+	fd, err := strconv.Atoi(value)
+	if err != nil || fd < 0 {
+		return "", fmt.Errorf("invalid %s: %q", PassphraseFDEnvVar, value)
+	}
+	file := os.NewFile(uintptr(fd), "passphrase")
+	if file == nil {
+		return "", fmt.Errorf("invalid %s: %q", PassphraseFDEnvVar, value)
+	}
+	defer file.Close()
+
+	line, err := bufio.NewReader(file).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read passphrase from fd %d: %w", fd, err)
+	}
+	passphrase := strings.TrimRight(line, "\r\n")
+	if passphrase == "" {
+		return "", fmt.Errorf("empty passphrase read from fd %d", fd)
+	}
+	fdPassphrase = passphrase
+	return passphrase, nil
+}
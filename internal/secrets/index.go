@@ -0,0 +1,141 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+const keyIndexFileName = "key-index.json"
+
+type KeyInfo struct {
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	LastUsed  time.Time `json:"last_used,omitzero"`
+}
+
+type keyIndex map[string]map[string]KeyInfo
+
+var indexMu sync.Mutex
+
+func keyIndexPath() (string, error) {
+	dir, err := config.EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, keyIndexFileName), nil
+}
+
+func loadKeyIndex() (keyIndex, error) {
+	path, err := keyIndexPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return keyIndex{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key index: %w", err)
+	}
+	index := keyIndex{}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse key index: %w", err)
+	}
+	return index, nil
+}
+
+func (i keyIndex) save() error {
+	path, err := keyIndexPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode key index: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write key index: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write key index: %w", err)
+	}
+	return nil
+}
+
+func updateKeyIndex(service string, update func(keys map[string]KeyInfo) bool) error {
+	indexMu.Lock()
+	defer indexMu.Unlock()
+	index, err := loadKeyIndex()
+	if err != nil {
+		return err
+	}
+	if index[service] == nil {
+		index[service] = make(map[string]KeyInfo)
+	}
+	if !update(index[service]) {
+		return nil
+	}
+	if len(index[service]) == 0 {
+		delete(index, service)
+	}
+	return index.save()
+}
+
+func indexStored(service, key string) error {
+	return updateKeyIndex(service, func(keys map[string]KeyInfo) bool {
+		info := keys[key]
+		info.UpdatedAt = time.Now()
+		keys[key] = info
+		return true
+	})
+}
+
+func indexKnown(service, key string) error {
+	return updateKeyIndex(service, func(keys map[string]KeyInfo) bool {
+		if _, ok := keys[key]; ok {
+			return false
+		}
+		keys[key] = KeyInfo{}
+		return true
+	})
+}
+
+func indexDeleted(service, key string) error {
+	return updateKeyIndex(service, func(keys map[string]KeyInfo) bool {
+		if _, ok := keys[key]; !ok {
+			return false
+		}
+		delete(keys, key)
+		return true
+	})
+}
+
+func indexUsed(service, key string) error {
+	return updateKeyIndex(service, func(keys map[string]KeyInfo) bool {
+		info := keys[key]
+		info.LastUsed = time.Now()
+		keys[key] = info
+		return true
+	})
+}
+
+func IndexedKeys(service string) (map[string]KeyInfo, error) {
+	indexMu.Lock()
+	defer indexMu.Unlock()
+	index, err := loadKeyIndex()
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]KeyInfo, len(index[service]))
+	for key, info := range index[service] {
+		keys[key] = info
+	}
+	return keys, nil
+}
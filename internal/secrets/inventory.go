@@ -0,0 +1,210 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+type KeyEntry struct {
+	Ref       string    `json:"ref"`
+	Scope     Scope     `json:"scope,omitempty"`
+	Name      string    `json:"name"`
+	Provider  string    `json:"provider,omitempty"`
+	Source    string    `json:"source"`
+	Value     string    `json:"-"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	LastUsed  time.Time `json:"last_used,omitzero"`
+	Agents    []string  `json:"agents,omitempty"`
+}
+
+func (e KeyEntry) Stored() bool {
+	return e.Scope != ""
+}
+
+func ParseKeyName(key string) (Scope, string, bool) {
+	parts := strings.Split(key, ":")
+	if len(parts) != 3 || parts[1] == "" {
+		return "", "", false
+	}
+	switch {
+	case Scope(parts[0]) == ScopeOAuth && parts[2] == "token":
+		return ScopeOAuth, parts[1], true
+	case Scope(parts[0]) == ScopeAgent || Scope(parts[0]) == ScopeProvider:
+		if parts[2] == "api-key" {
+			return Scope(parts[0]), parts[1], true
+		}
+	}
+	return "", "", false
+}
+
+func (k *KeyStore) Inventory(cfg *config.Config) ([]KeyEntry, error) {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+
+	if _, isFile := k.Backend().(*FileBackend); !isFile {
+		candidates, err := CandidateKeys(k.Backend(), k.service, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := k.Reindex(candidates); err != nil {
+			return nil, err
+		}
+	}
+
+	stored, err := k.ListKeys()
+	if err != nil {
+		return nil, err
+	}
+	indexed, err := IndexedKeys(k.service)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []KeyEntry
+	for _, ref := range stored {
+		value, err := k.Retrieve(ref)
+		if err != nil {
+			continue
+		}
+		entry := KeyEntry{
+			Ref:       ref,
+			Name:      ref,
+			Source:    k.Backend().Name(),
+			Value:     value,
+			UpdatedAt: indexed[ref].UpdatedAt,
+			LastUsed:  indexed[ref].LastUsed,
+		}
+		if scope, name, ok := ParseKeyName(ref); ok {
+			entry.Scope = scope
+			entry.Name = name
+			entry.Provider = name
+			if scope == ScopeAgent {
+				entry.Provider = ""
+				if agent, err := cfg.GetAgentByName(name); err == nil {
+					entry.Provider = string(agent.Provider)
+				}
+			}
+			if scope == ScopeOAuth {
+				if token, err := parseOAuthToken(value); err == nil {
+					entry.Value = token.AccessToken
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	providers := make(map[string]bool)
+	for name := range catalog.ProviderConfigs {
+		providers[name] = true
+	}
+	for _, agent := range cfg.Agents {
+		providers[string(agent.Provider)] = true
+	}
+	seenEnv := make(map[string]bool)
+	for _, provider := range sortedKeys(providers) {
+		envVar := EnvVarForProvider(config.AgentProvider(provider))
+		names := []string{ProfileEnvVar(k.profile, envVar)}
+		if k.globalKeys {
+			names = append(names, envVar)
+		}
+		for _, name := range names {
+			value := os.Getenv(name)
+			if name == "" || value == "" || seenEnv[name] {
+				continue
+			}
+			seenEnv[name] = true
+			entries = append(entries, KeyEntry{
+				Ref:      "env:" + name,
+				Name:     name,
+				Provider: provider,
+				Source:   string(SourceEnv),
+				Value:    value,
+			})
+		}
+	}
+
+	if auth, err := LoadOpenCodeAuth(); err == nil && k.globalKeys {
+		names := auth.ListProviders()
+		sort.Strings(names)
+		for _, provider := range names {
+			value, ok := OpenCodeAccessToken(context.Background(), provider)
+			if !ok {
+				continue
+			}
+			entries = append(entries, KeyEntry{
+				Ref:      "opencode:" + provider,
+				Name:     provider,
+				Provider: provider,
+				Source:   string(SourceOpenCode),
+				Value:    value,
+			})
+		}
+	}
+
+	byRef := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		byRef[entry.Ref] = i
+	}
+	for _, agent := range cfg.Agents {
+		resolved := k.Resolve(agent)
+		i, ok := byRef[resolved.Ref]
+		if !ok && agent.KeySource != "" && resolved.Key != "" {
+			_, name, _ := config.ParseKeySource(resolved.Ref)
+			entries = append(entries, KeyEntry{
+				Ref:      resolved.Ref,
+				Name:     name,
+				Provider: string(agent.Provider),
+				Source:   string(resolved.Source),
+				Value:    resolved.Key,
+			})
+			i, ok = len(entries)-1, true
+			byRef[resolved.Ref] = i
+		}
+		if ok {
+			entries[i].Agents = append(entries[i].Agents, agent.Name)
+		}
+	}
+
+	return entries, nil
+}
+
+func FindKeyEntry(entries []KeyEntry, cfg *config.Config, query string) (KeyEntry, bool) {
+	for _, entry := range entries {
+		if entry.Ref == query || entry.Ref == query+":api-key" {
+			return entry, true
+		}
+	}
+	for _, entry := range entries {
+		if !entry.Stored() && (entry.Name == query || entry.Source+":"+entry.Name == query) {
+			return entry, true
+		}
+	}
+	if cfg != nil {
+		if _, err := cfg.GetAgentByName(query); err == nil {
+			for _, entry := range entries {
+				for _, agent := range entry.Agents {
+					if agent == query {
+						return entry, true
+					}
+				}
+			}
+		}
+	}
+	return KeyEntry{}, false
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,253 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+type Scope string
+
+const (
+	ScopeAgent    Scope = "agent"
+	ScopeProvider Scope = "provider"
+	ScopeOAuth    Scope = "oauth"
+)
+
+type Source string
+
+const (
+	SourceNone     Source = ""
+	SourceEnv      Source = "env"
+	SourceAgent    Source = "agent key"
+	SourceProvider Source = "provider key"
+	SourceOAuth    Source = "oauth login"
+	SourceCommand  Source = "command"
+	SourceFile     Source = "file"
+	SourceOpenCode Source = "opencode"
+)
+
+type KeyStore struct {
+	*Manager
+	profile    string
+	globalKeys bool
+}
+
+func NewKeyStore() *KeyStore {
+	return NewProfileKeyStore(config.ActiveProfile())
+}
+
+func NewProfileKeyStore(profile string) *KeyStore {
+	return &KeyStore{
+		Manager:    NewManagerWithService(ServiceForProfile(profile)),
+		profile:    profile,
+		globalKeys: profileUsesGlobalKeys(profile),
+	}
+}
+
+func profileUsesGlobalKeys(profile string) bool {
+	if profile == "" || profile == config.DefaultProfile {
+		return true
+	}
+	index, err := config.LoadProfiles()
+	if err != nil {
+		return false
+	}
+	p, ok := index.Get(profile)
+	return ok && p.GlobalKeys
+}
+
+func ServiceForProfile(profile string) string {
+	if profile == "" || profile == config.DefaultProfile {
+		return ServiceName
+	}
+	return ServiceName + ":" + profile
+}
+
+func KeyName(scope Scope, name string) string {
+	return fmt.Sprintf("%s:%s:api-key", scope, name)
+}
+
+func (k *KeyStore) Set(scope Scope, name, apiKey string) error {
+	return k.Store(KeyName(scope, name), apiKey)
+}
+
+func (k *KeyStore) Get(scope Scope, name string) (string, error) {
+	return k.Retrieve(KeyName(scope, name))
+}
+
+func (k *KeyStore) Remove(scope Scope, name string) error {
+	return k.Delete(KeyName(scope, name))
+}
+
+func (k *KeyStore) Has(scope Scope, name string) bool {
+	return k.KeyExists(KeyName(scope, name))
+}
+
+func (k *KeyStore) StoreAgentKey(agentName, apiKey string) error {
+	return k.Set(ScopeAgent, agentName, apiKey)
+}
+
+func (k *KeyStore) RetrieveAgentKey(agentName string) (string, error) {
+	return k.Get(ScopeAgent, agentName)
+}
+
+func (k *KeyStore) DeleteAgentKey(agentName string) error {
+	return k.Remove(ScopeAgent, agentName)
+}
+
+func (k *KeyStore) GetAgentKeyName(agentName string) string {
+	return KeyName(ScopeAgent, agentName)
+}
+
+func (k *KeyStore) StoreProviderKey(provider, apiKey string) error {
+	return k.Set(ScopeProvider, provider, apiKey)
+}
+
+func (k *KeyStore) RetrieveProviderKey(provider string) (string, error) {
+	return k.Get(ScopeProvider, provider)
+}
+
+func (k *KeyStore) DeleteProviderKey(provider string) error {
+	return k.Remove(ScopeProvider, provider)
+}
+
+type Resolution struct {
+	Key    string
+	Source Source
+	Ref    string
+	Err    error
+}
+
+func (k *KeyStore) Resolve(profile config.AgentProfile) Resolution {
+	if profile.KeySource != "" {
+		return k.resolveKeySource(profile.KeySource)
+	}
+
+	envVar := EnvVarForProvider(profile.Provider)
+	scopedEnvVar := ProfileEnvVar(k.profile, envVar)
+	if r, ok := resolveEnv(scopedEnvVar); ok {
+		return r
+	}
+	if scopedEnvVar == "" {
+		if r, ok := resolveEnv(envVar); ok {
+			return r
+		}
+	}
+
+	var failed Resolution
+	lookup := func(ref string) (string, bool) {
+		apiKey, err := k.Backend().Get(k.service, ref)
+		if err == nil {
+			return apiKey, true
+		}
+		if !errors.Is(err, ErrNotFound) && failed.Err == nil {
+			failed = Resolution{Ref: ref, Err: fmt.Errorf("%s backend: %w", k.Backend().Name(), err)}
+		}
+		return "", false
+	}
+
+	if profile.Name != "" {
+		if apiKey, ok := lookup(KeyName(ScopeAgent, profile.Name)); ok {
+			return Resolution{Key: apiKey, Source: SourceAgent, Ref: KeyName(ScopeAgent, profile.Name)}
+		}
+	}
+
+	if apiKey, ok := lookup(KeyName(ScopeProvider, string(profile.Provider))); ok {
+		return Resolution{Key: apiKey, Source: SourceProvider, Ref: KeyName(ScopeProvider, string(profile.Provider))}
+	}
+
+	if scopedEnvVar != "" && k.globalKeys {
+		if r, ok := resolveEnv(envVar); ok {
+			return r
+		}
+	}
+
+	if token, ok := k.OAuthAccessToken(context.Background(), string(profile.Provider)); ok {
+		return Resolution{Key: token, Source: SourceOAuth, Ref: OAuthKeyName(string(profile.Provider))}
+	}
+
+	if !k.globalKeys {
+		return failed
+	}
+	if apiKey, ok := OpenCodeAccessToken(context.Background(), string(profile.Provider)); ok {
+		return Resolution{Key: apiKey, Source: SourceOpenCode, Ref: "opencode:" + OpenCodeAuth(nil).MapProvider(string(profile.Provider))}
+	}
+
+	return failed
+}
+
+func resolveEnv(envVar string) (Resolution, bool) {
+	if envVar == "" {
+		return Resolution{}, false
+	}
+	apiKey := os.Getenv(envVar)
+	if apiKey == "" {
+		return Resolution{}, false
+	}
+	return Resolution{Key: apiKey, Source: SourceEnv, Ref: "env:" + envVar}, true
+}
+
+func (k *KeyStore) ResolveAPIKey(profile config.AgentProfile) (string, Source) {
+	r := k.Resolve(profile)
+	return r.Key, r.Source
+}
+
+func (k *KeyStore) MarkResolved(r Resolution) {
+	if r.Source == SourceAgent || r.Source == SourceProvider || r.Source == SourceOAuth {
+		k.MarkUsed(r.Ref)
+	}
+}
+
+func (k *KeyStore) ResolveProviderKey(provider string) (string, Source) {
+	return k.ResolveAPIKey(config.AgentProfile{Provider: config.AgentProvider(provider)})
+}
+
+func (k *KeyStore) EnvVar(provider config.AgentProvider) string {
+	envVar := EnvVarForProvider(provider)
+	if scoped := ProfileEnvVar(k.profile, envVar); scoped != "" {
+		return scoped
+	}
+	return envVar
+}
+
+func ProfileEnvVar(profile, envVar string) string {
+	if profile == "" || profile == config.DefaultProfile || envVar == "" {
+		return ""
+	}
+	return "GS_" + strings.ToUpper(strings.ReplaceAll(profile, "-", "_")) + "_" + envVar
+}
+
+func EnvVarForProvider(provider config.AgentProvider) string {
+	if pConfig, ok := catalog.GetProviderConfig(string(provider)); ok && pConfig.EnvVar != "" {
+		return pConfig.EnvVar
+	}
+
+	switch provider {
+	case config.ProviderOpenAI:
+		return "OPENAI_API_KEY"
+	case config.ProviderGroq:
+		return "GROQ_API_KEY"
+	case config.ProviderClaude:
+		return "ANTHROPIC_API_KEY"
+	case config.ProviderGemini:
+		return "GEMINI_API_KEY"
+	case config.ProviderOpenRouter:
+		return "OPENROUTER_API_KEY"
+	case config.ProviderOpenCode:
+		return "OPENCODE_API_KEY"
+	case config.ProviderHackClub:
+		return "HACKCLUB_API_KEY"
+	case config.ProviderAzure:
+		return "AZURE_OPENAI_API_KEY"
+	case config.ProviderBedrock:
+		return "AWS_ACCESS_KEY_ID"
+	default:
+		return strings.ToUpper(string(provider)) + "_API_KEY"
+	}
+}
@@ -0,0 +1,100 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/albuquerquesz/gitscribe/internal/auth"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+	"github.com/albuquerquesz/gitscribe/internal/providers"
+)
+
+func OAuthKeyName(provider string) string {
+	return fmt.Sprintf("%s:%s:token", ScopeOAuth, provider)
+}
+
+func (k *KeyStore) StoreOAuthToken(provider string, token *auth.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to encode token: %w", err)
+	}
+	return k.Store(OAuthKeyName(provider), string(data))
+}
+
+func (k *KeyStore) LoadOAuthToken(provider string) (*auth.Token, error) {
+	value, err := k.Retrieve(OAuthKeyName(provider))
+	if err != nil {
+		return nil, err
+	}
+	return parseOAuthToken(value)
+}
+
+func (k *KeyStore) DeleteOAuthToken(provider string) error {
+	return k.Delete(OAuthKeyName(provider))
+}
+
+func (k *KeyStore) OAuthAccessToken(ctx context.Context, provider string) (string, bool) {
+	token, err := k.LoadOAuthToken(provider)
+	if err != nil {
+		return "", false
+	}
+	if !token.ExpiresWithin(oauthRefreshMargin) {
+		return token.AccessToken, true
+	}
+	if token.RefreshToken != "" {
+		access, err := k.RefreshOAuthToken(ctx, provider, token.AccessToken)
+		if err == nil {
+			return access, true
+		}
+		logging.Warn("oauth token refresh failed", "provider", provider, "error", err)
+	}
+	if token.Expired() {
+		return "", false
+	}
+	return token.AccessToken, true
+}
+
+func (k *KeyStore) RefreshOAuthToken(ctx context.Context, providerName, staleToken string) (string, error) {
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+
+	token, err := k.LoadOAuthToken(providerName)
+	if err != nil {
+		return "", fmt.Errorf("no OAuth login for %s: %w", providerName, err)
+	}
+	if token.AccessToken != staleToken && !token.ExpiresWithin(oauthRefreshMargin) {
+		return token.AccessToken, nil
+	}
+	if token.RefreshToken == "" {
+		return "", ErrNoRefreshToken
+	}
+	provider, ok := providers.Get(providerName)
+	if !ok {
+		return "", fmt.Errorf("token refresh is not supported for %s", providerName)
+	}
+
+	refreshed, err := auth.Refresh(ctx, provider.Endpoint(), token.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+	if refreshed.Scope == "" {
+		refreshed.Scope = token.Scope
+	}
+	if err := k.StoreOAuthToken(providerName, refreshed); err != nil {
+		return "", fmt.Errorf("failed to save refreshed token: %w", err)
+	}
+	logging.Info("refreshed oauth token", "provider", providerName, "expires", refreshed.ExpiresAt)
+	return refreshed.AccessToken, nil
+}
+
+func parseOAuthToken(value string) (*auth.Token, error) {
+	var token auth.Token
+	if err := json.Unmarshal([]byte(value), &token); err != nil {
+		return nil, fmt.Errorf("failed to parse stored token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("stored token has no access token")
+	}
+	return &token, nil
+}
@@ -0,0 +1,155 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	legacyStoreService = "gitscribe"
+	legacyStoreUser    = "anon"
+	legacyAuthService  = "gitscribe-api-keys"
+	legacyMarkerName   = ".keys-migrated"
+)
+
+type legacyEntry struct {
+	service string
+	user    string
+	scope   Scope
+	name    string
+}
+
+func legacyMarkerPath(profile string) (string, error) {
+	dir, err := config.EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+	name := legacyMarkerName
+	if profile != "" && profile != config.DefaultProfile {
+		name += "-" + profile
+	}
+	return filepath.Join(dir, name), nil
+}
+
+func LegacyKeysMigrated(profile string) bool {
+	path, err := legacyMarkerPath(profile)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+func MigrateLegacyKeys(profile string, cfg *config.Config) (int, error) {
+	if LegacyKeysMigrated(profile) {
+		return 0, nil
+	}
+
+	store := NewProfileKeyStore(profile)
+	if err := KeyringAvailable(); err != nil {
+		if store.Backend().Name() == config.SecretsBackendKeyring {
+			return 0, fmt.Errorf("keyring unavailable: %w", err)
+		}
+		return 0, markLegacyKeysMigrated(profile)
+	}
+	moved := 0
+	for _, entry := range legacyEntries(profile, cfg) {
+		ok, err := store.migrateEntry(entry)
+		if err != nil {
+			return moved, err
+		}
+		if ok {
+			moved++
+		}
+	}
+	return moved, markLegacyKeysMigrated(profile)
+}
+
+func markLegacyKeysMigrated(profile string) error {
+	path, err := legacyMarkerPath(profile)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte("1\n"), 0600); err != nil {
+		return fmt.Errorf("failed to record key migration: %w", err)
+	}
+	return nil
+}
+
+func legacyEntries(profile string, cfg *config.Config) []legacyEntry {
+	var entries []legacyEntry
+	if profile == "" || profile == config.DefaultProfile {
+		entries = append(entries, legacyEntry{legacyStoreService, legacyStoreUser, ScopeProvider, string(config.ProviderGroq)})
+	}
+
+	authService := legacyAuthService
+	if profile != "" && profile != config.DefaultProfile {
+		authService += ":" + profile
+	}
+
+	providers := make(map[string]bool)
+	for name := range catalog.ProviderConfigs {
+		providers[name] = true
+	}
+	agentNames := make(map[string]bool)
+	if cfg != nil {
+		for _, p := range cfg.Providers {
+			providers[p.Name] = true
+		}
+		for _, a := range cfg.Agents {
+			agentNames[a.Name] = true
+		}
+	}
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		entries = append(entries, legacyEntry{authService, name + "-api-key", ScopeProvider, name})
+		if !agentNames[name] {
+			entries = append(entries, legacyEntry{ServiceForProfile(profile), KeyName(ScopeAgent, name), ScopeProvider, name})
+		}
+	}
+	return entries
+}
+
+func (k *KeyStore) migrateEntry(entry legacyEntry) (bool, error) {
+	value, err := keyring.Get(entry.service, entry.user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s/%s: %w", entry.service, entry.user, err)
+	}
+
+	target := KeyName(entry.scope, entry.name)
+	existing, err := k.Backend().Get(k.service, target)
+	switch {
+	case err == nil && existing != value:
+		logging.Warn("legacy key not migrated, target already set", "service", entry.service, "key", entry.user, "target", target)
+		return false, nil
+	case err != nil && !errors.Is(err, ErrNotFound):
+		return false, fmt.Errorf("failed to read %s: %w", target, err)
+	case err != nil:
+		if err := k.Set(entry.scope, entry.name, value); err != nil {
+			return false, fmt.Errorf("failed to store %s: %w", target, err)
+		}
+	}
+
+	if err := keyring.Delete(entry.service, entry.user); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return false, fmt.Errorf("failed to remove %s/%s: %w", entry.service, entry.user, err)
+	}
+	logging.Info("migrated legacy key", "service", entry.service, "key", entry.user, "target", target)
+	return true, nil
+}
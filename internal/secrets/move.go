@@ -0,0 +1,86 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+type MoveResult struct {
+	Moved     []string
+	Conflicts []string
+}
+
+func CandidateKeys(backend Backend, service string, cfg *config.Config) ([]string, error) {
+	if file, ok := backend.(*FileBackend); ok {
+		return file.Keys(service)
+	}
+
+	seen := make(map[string]bool)
+	for name, pConfig := range catalog.ProviderConfigs {
+		seen[KeyName(ScopeProvider, name)] = true
+		if pConfig.SupportsOAuth2 {
+			seen[OAuthKeyName(name)] = true
+		}
+	}
+	if cfg != nil {
+		for _, p := range cfg.Providers {
+			seen[KeyName(ScopeProvider, p.Name)] = true
+		}
+		for _, a := range cfg.Agents {
+			seen[KeyName(ScopeAgent, a.Name)] = true
+			if a.KeyringKey != "" {
+				seen[a.KeyringKey] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func MoveKeys(service string, from, to Backend, keys []string, keep, force bool) (*MoveResult, error) {
+	if from.Name() == to.Name() {
+		return nil, fmt.Errorf("source and destination are both %s", from.Name())
+	}
+
+	result := &MoveResult{}
+	for _, key := range keys {
+		value, err := from.Get(service, key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to read %s from %s: %w", key, from.Name(), err)
+		}
+
+		existing, err := to.Get(service, key)
+		switch {
+		case err == nil && existing == value:
+		case err == nil && !force:
+			result.Conflicts = append(result.Conflicts, key)
+			continue
+		case err != nil && !errors.Is(err, ErrNotFound):
+			return result, fmt.Errorf("failed to read %s from %s: %w", key, to.Name(), err)
+		default:
+			if err := to.Set(service, key, value); err != nil {
+				return result, fmt.Errorf("failed to write %s to %s: %w", key, to.Name(), err)
+			}
+		}
+
+		if !keep {
+			if err := from.Delete(service, key); err != nil && !errors.Is(err, ErrNotFound) {
+				return result, fmt.Errorf("failed to remove %s from %s: %w", key, from.Name(), err)
+			}
+		}
+		result.Moved = append(result.Moved, key)
+	}
+	return result, nil
+}
@@ -0,0 +1,191 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/auth"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+	"github.com/albuquerquesz/gitscribe/internal/providers"
+)
+
+const oauthRefreshMargin = time.Minute
+
+var ErrNoRefreshToken = errors.New("no refresh token available")
+
+var (
+	refreshMu       sync.Mutex
+	refreshedTokens = make(map[string]OpenCodeAuthEntry)
+)
+
+func (o OpenCodeAuth) entry(provider string) (OpenCodeAuthEntry, bool) {
+	if o == nil {
+		return OpenCodeAuthEntry{}, false
+	}
+	entry, ok := o[o.MapProvider(provider)]
+	return entry, ok
+}
+
+func (o OpenCodeAuth) CanRefresh(provider string) bool {
+	entry, ok := o.entry(provider)
+	if !ok || entry.Type != "oauth" || entry.Refresh == "" {
+		return false
+	}
+	_, known := providers.Get(o.MapProvider(provider))
+	return known
+}
+
+func (o OpenCodeAuth) NeedsRefresh(provider string) bool {
+	entry, ok := o.entry(provider)
+	if !ok || entry.Type != "oauth" {
+		return false
+	}
+	return entry.Access == "" || o.IsTokenExpiringSoon(provider, oauthRefreshMargin)
+}
+
+func OpenCodeAccessToken(ctx context.Context, provider string) (string, bool) {
+	opencode, err := LoadOpenCodeAuth()
+	if err != nil || opencode == nil {
+		return "", false
+	}
+
+	if token, ok := cachedOpenCodeToken(opencode.MapProvider(provider)); ok {
+		return token, true
+	}
+
+	if opencode.NeedsRefresh(provider) && opencode.CanRefresh(provider) {
+		entry, _ := opencode.entry(provider)
+		token, err := RefreshOpenCodeToken(ctx, provider, entry.Access)
+		if err == nil {
+			return token, true
+		}
+		logging.Warn("opencode token refresh failed", "provider", provider, "error", err)
+	}
+
+	return opencode.GetAPIKey(provider)
+}
+
+func cachedOpenCodeToken(name string) (string, bool) {
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+	entry, ok := refreshedTokens[name]
+	if !ok || expiringSoon(entry) {
+		return "", false
+	}
+	return entry.Access, true
+}
+
+func RefreshOpenCodeToken(ctx context.Context, providerName, staleToken string) (string, error) {
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+
+	opencode, err := LoadOpenCodeAuth()
+	if err != nil {
+		return "", err
+	}
+	name := opencode.MapProvider(providerName)
+	entry, ok := opencode[name]
+	if !ok || entry.Type != "oauth" {
+		return "", fmt.Errorf("no OpenCode OAuth login for %s", providerName)
+	}
+	if cached, ok := refreshedTokens[name]; ok && cached.Expires >= entry.Expires {
+		entry = cached
+	}
+	if entry.Access != "" && entry.Access != staleToken && !expiringSoon(entry) {
+		return entry.Access, nil
+	}
+	if entry.Refresh == "" {
+		return "", ErrNoRefreshToken
+	}
+	provider, ok := providers.Get(name)
+	if !ok {
+		return "", fmt.Errorf("token refresh is not supported for %s", name)
+	}
+
+	token, err := auth.Refresh(ctx, provider.Endpoint(), entry.Refresh)
+	if err != nil {
+		return "", err
+	}
+
+	entry.Access = token.AccessToken
+	entry.Refresh = token.RefreshToken
+	entry.Expires = 0
+	if !token.ExpiresAt.IsZero() {
+		entry.Expires = token.ExpiresAt.UnixMilli()
+	}
+	refreshedTokens[name] = entry
+
+	if err := writeOpenCodeEntry(name, entry); err != nil {
+		logging.Warn("failed to save refreshed opencode token, keeping it for this run only", "provider", name, "error", err)
+	} else {
+		logging.Info("refreshed opencode token", "provider", name, "expires", time.UnixMilli(entry.Expires))
+	}
+	return entry.Access, nil
+}
+
+func expiringSoon(entry OpenCodeAuthEntry) bool {
+	return entry.Expires > 0 && time.Until(time.UnixMilli(entry.Expires)) < oauthRefreshMargin
+}
+
+func writeOpenCodeEntry(name string, entry OpenCodeAuthEntry) error {
+	path, err := GetOpenCodeAuthPath()
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var doc map[string]map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse opencode auth: %w", err)
+	}
+	fields := doc[name]
+	if fields == nil {
+		fields = make(map[string]any)
+		doc[name] = fields
+	}
+	fields["type"] = entry.Type
+	fields["access"] = entry.Access
+	fields["refresh"] = entry.Refresh
+	if entry.Expires > 0 {
+		fields["expires"] = entry.Expires
+	} else {
+		delete(fields, "expires")
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".auth-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(out); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
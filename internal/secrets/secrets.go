@@ -1,32 +1,53 @@
 package secrets
 
 import (
+	"errors"
 	"fmt"
-	"runtime"
+	"sort"
+	"strings"
 
-	"github.com/zalando/go-keyring"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
 )
 
 const (
-	ServiceName = "multiagent-cli"
+	ServiceName   = "multiagent-cli"
+	metaSeparator = ":meta:"
 )
 
 type Manager struct {
 	service string
+	backend Backend
 }
 
 func NewManager() *Manager {
+	return NewManagerWithService(ServiceName)
+}
+
+func NewManagerWithService(service string) *Manager {
 	return &Manager{
-		service: ServiceName,
+		service: service,
 	}
 }
 
-func NewManagerWithService(service string) *Manager {
+func NewManagerWithBackend(service string, backend Backend) *Manager {
 	return &Manager{
 		service: service,
+		backend: backend,
 	}
 }
 
+func (m *Manager) Backend() Backend {
+	if m.backend != nil {
+		return m.backend
+	}
+	backend, _ := ActiveBackend()
+	return backend
+}
+
+func (m *Manager) Service() string {
+	return m.service
+}
+
 func (m *Manager) Store(keyName string, apiKey string) error {
 	if keyName == "" {
 		return fmt.Errorf("key name cannot be empty")
@@ -34,16 +55,22 @@ func (m *Manager) Store(keyName string, apiKey string) error {
 	if apiKey == "" {
 		return fmt.Errorf("API key cannot be empty")
 	}
-	return keyring.Set(m.service, keyName, apiKey)
+	if err := m.Backend().Set(m.service, keyName, apiKey); err != nil {
+		return err
+	}
+	if err := indexStored(m.service, keyName); err != nil {
+		logging.Debug("failed to update key index", "key", keyName, "error", err)
+	}
+	return nil
 }
 
 func (m *Manager) Retrieve(keyName string) (string, error) {
 	if keyName == "" {
 		return "", fmt.Errorf("key name cannot be empty")
 	}
-	apiKey, err := keyring.Get(m.service, keyName)
+	apiKey, err := m.Backend().Get(m.service, keyName)
 	if err != nil {
-		if err == keyring.ErrNotFound {
+		if errors.Is(err, ErrNotFound) {
 			return "", fmt.Errorf("API key not found for: %s", keyName)
 		}
 		return "", fmt.Errorf("failed to retrieve API key: %w", err)
@@ -55,32 +82,72 @@ func (m *Manager) Delete(keyName string) error {
 	if keyName == "" {
 		return fmt.Errorf("key name cannot be empty")
 	}
-	return keyring.Delete(m.service, keyName)
+	err := m.Backend().Delete(m.service, keyName)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if ierr := indexDeleted(m.service, keyName); ierr != nil {
+		logging.Debug("failed to update key index", "key", keyName, "error", ierr)
+	}
+	return err
 }
 
 func (m *Manager) ListKeys() ([]string, error) {
-	switch runtime.GOOS {
-	case "darwin":
-		return m.listKeysMacOS()
-	case "linux":
-		return m.listKeysLinux()
-	case "windows":
-		return m.listKeysWindows()
-	default:
-		return nil, fmt.Errorf("unsupported platform for listing keys: %s", runtime.GOOS)
+	var keys []string
+	if file, ok := m.Backend().(*FileBackend); ok {
+		stored, err := file.Keys(m.service)
+		if err != nil {
+			return nil, err
+		}
+		keys = stored
+	} else {
+		indexed, err := IndexedKeys(m.service)
+		if err != nil {
+			return nil, err
+		}
+		for key := range indexed {
+			keys = append(keys, key)
+		}
 	}
-}
 
-func (m *Manager) listKeysMacOS() ([]string, error) {
-	return nil, fmt.Errorf("key listing not yet implemented for macOS")
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !strings.Contains(key, metaSeparator) {
+			result = append(result, key)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
 }
 
-func (m *Manager) listKeysLinux() ([]string, error) {
-	return nil, fmt.Errorf("key listing not yet implemented for Linux")
+func (m *Manager) Reindex(candidates []string) (int, error) {
+	indexed, err := IndexedKeys(m.service)
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, key := range candidates {
+		if _, ok := indexed[key]; ok {
+			continue
+		}
+		if _, err := m.Backend().Get(m.service, key); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return added, err
+		}
+		if err := indexKnown(m.service, key); err != nil {
+			return added, err
+		}
+		added++
+	}
+	return added, nil
 }
 
-func (m *Manager) listKeysWindows() ([]string, error) {
-	return nil, fmt.Errorf("key listing not yet implemented for Windows")
+func (m *Manager) MarkUsed(keyName string) {
+	if err := indexUsed(m.service, keyName); err != nil {
+		logging.Debug("failed to update key index", "key", keyName, "error", err)
+	}
 }
 
 func (m *Manager) StoreWithMetadata(keyName string, apiKey string, metadata map[string]string) error {
@@ -88,8 +155,8 @@ func (m *Manager) StoreWithMetadata(keyName string, apiKey string, metadata map[
 		return err
 	}
 	for k, v := range metadata {
-		metaKey := fmt.Sprintf("%s:meta:%s", keyName, k)
-		if err := keyring.Set(m.service, metaKey, v); err != nil {
+		metaKey := keyName + metaSeparator + k
+		if err := m.Backend().Set(m.service, metaKey, v); err != nil {
 			return fmt.Errorf("failed to store metadata: %w", err)
 		}
 	}
@@ -97,8 +164,8 @@ func (m *Manager) StoreWithMetadata(keyName string, apiKey string, metadata map[
 }
 
 func (m *Manager) RetrieveMetadata(keyName string, metaKey string) (string, error) {
-	fullKey := fmt.Sprintf("%s:meta:%s", keyName, metaKey)
-	return keyring.Get(m.service, fullKey)
+	fullKey := keyName + metaSeparator + metaKey
+	return m.Backend().Get(m.service, fullKey)
 }
 
 func (m *Manager) KeyExists(keyName string) bool {
@@ -112,32 +179,3 @@ func SecureWipe(s *string) {
 	}
 	*s = ""
 }
-
-type AgentKeyManager struct {
-	*Manager
-}
-
-func NewAgentKeyManager() *AgentKeyManager {
-	return &AgentKeyManager{
-		Manager: NewManager(),
-	}
-}
-
-func (a *AgentKeyManager) StoreAgentKey(agentName string, apiKey string) error {
-	keyName := fmt.Sprintf("agent:%s:api-key", agentName)
-	return a.Store(keyName, apiKey)
-}
-
-func (a *AgentKeyManager) RetrieveAgentKey(agentName string) (string, error) {
-	keyName := fmt.Sprintf("agent:%s:api-key", agentName)
-	return a.Retrieve(keyName)
-}
-
-func (a *AgentKeyManager) DeleteAgentKey(agentName string) error {
-	keyName := fmt.Sprintf("agent:%s:api-key", agentName)
-	return a.Delete(keyName)
-}
-
-func (a *AgentKeyManager) GetAgentKeyName(agentName string) string {
-	return fmt.Sprintf("agent:%s:api-key", agentName)
-}
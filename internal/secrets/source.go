@@ -0,0 +1,143 @@
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/config"
+	"github.com/albuquerquesz/gitscribe/internal/logging"
+)
+
+const keyCommandTimeout = 30 * time.Second
+
+var (
+	commandKeysMu sync.Mutex
+	commandKeys   = make(map[string]string)
+)
+
+func ReadKeySource(ctx context.Context, ref string) (string, error) {
+	kind, value, err := config.ParseKeySource(ref)
+	if err != nil {
+		return "", err
+	}
+	switch kind {
+	case config.KeySourceCommand:
+		return readKeyCommand(ctx, value)
+	case config.KeySourceFile:
+		return readKeyFile(value)
+	default:
+		key := strings.TrimSpace(os.Getenv(value))
+		if key == "" {
+			return "", fmt.Errorf("environment variable %s is not set", value)
+		}
+		return key, nil
+	}
+}
+
+func readKeyCommand(ctx context.Context, command string) (string, error) {
+	commandKeysMu.Lock()
+	defer commandKeysMu.Unlock()
+	if key, ok := commandKeys[command]; ok {
+		return key, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, keyCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	logging.Debug("key command finished", "duration", time.Since(start), "error", err)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("key command timed out after %s", keyCommandTimeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("key command failed: %w: %s", err, logging.Redact(firstLine(msg)))
+		}
+		return "", fmt.Errorf("key command failed: %w", err)
+	}
+
+	key := firstLine(stdout.String())
+	if key == "" {
+		return "", fmt.Errorf("key command printed nothing")
+	}
+	commandKeys[command] = key
+	return key, nil
+}
+
+func readKeyFile(path string) (string, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, rest)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read key file: %w", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		logging.Warn("key file is readable by other users", "path", path, "mode", info.Mode().Perm())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read key file: %w", err)
+	}
+	key := firstLine(string(data))
+	if key == "" {
+		return "", fmt.Errorf("key file %s is empty", path)
+	}
+	return key, nil
+}
+
+func firstLine(s string) string {
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func (k *KeyStore) resolveKeySource(ref string) Resolution {
+	kind, _, err := config.ParseKeySource(ref)
+	if err != nil {
+		return Resolution{Ref: ref, Err: err}
+	}
+	key, err := ReadKeySource(context.Background(), ref)
+	if err != nil {
+		return Resolution{Ref: ref, Err: err}
+	}
+	source := SourceEnv
+	switch kind {
+	case config.KeySourceCommand:
+		source = SourceCommand
+	case config.KeySourceFile:
+		source = SourceFile
+	}
+	return Resolution{Key: key, Source: source, Ref: ref}
+}
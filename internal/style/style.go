@@ -4,10 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"atomicgo.dev/keyboard"
-	"atomicgo.dev/keyboard/keys"
-	"github.com/albuquerquesz/gitscribe/internal/catalog"
-	"github.com/albuquerquesz/gitscribe/internal/config"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -137,43 +133,6 @@ func GetASCIIName() {
 	fmt.Println()
 }
 
-func SelectModel(manager *catalog.CatalogManager) (*catalog.Model, error) {
-	var selectedProvider string
-	var selectedModelID string
-
-	providers := manager.ListProviders()
-	var providerOpts []huh.Option[string]
-	for _, p := range providers {
-		providerOpts = append(providerOpts, huh.NewOption(formatProviderName(p), p))
-	}
-
-	if len(providerOpts) > 0 {
-		selectedProvider = providerOpts[0].Value
-	}
-
-	form := huh.NewForm(
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Select Provider").
-				Options(providerOpts...).
-				Value(&selectedProvider),
-
-			huh.NewSelect[string]().
-				Title("Select Model").
-				OptionsFunc(func() []huh.Option[string] {
-					return getModelOptions(manager, selectedProvider)
-				}, &selectedProvider).
-				Value(&selectedModelID),
-		),
-	).WithTheme(GetTheme())
-
-	if err := form.Run(); err != nil {
-		return nil, err
-	}
-
-	return manager.GetModel(selectedModelID)
-}
-
 func Prompt(label string) (string, error) {
 	var input string
 	err := huh.NewInput().
@@ -232,137 +191,3 @@ func Box(title, content string) {
 func InteractiveConfirm(msg string) bool {
 	return ConfirmAction(msg)
 }
-
-func EditMessage(current string) (string, error) {
-	edited := current
-
-	err := huh.NewInput().
-		Value(&edited).
-		WithTheme(GetTheme()).
-		Run()
-	if err != nil {
-		return "", err
-	}
-	return edited, nil
-}
-
-func ShowCommitPrompt(message string) (action string, finalMessage string) {
-	currentMessage := message
-	resultAction := ""
-	resultMessage := ""
-	done := false
-	lineIndex := 0
-
-	for !done {
-		messageLines := strings.Count(currentMessage, "\n") + 1
-		totalLines := messageLines + 3
-
-		if lineIndex > 0 {
-			fmt.Printf("\033[%dA", totalLines)
-			for range totalLines {
-				fmt.Print("\033[2K\n")
-			}
-			fmt.Printf("\033[%dA", totalLines)
-		}
-		lineIndex++
-
-		messageStyle := lipgloss.NewStyle().
-			Foreground(LightGrey).
-			MarginTop(1).
-			MarginBottom(1)
-		fmt.Println(messageStyle.Render(currentMessage))
-
-		keyStyle := lipgloss.NewStyle().Foreground(White)
-		bracketStyle := lipgloss.NewStyle().Foreground(Grey)
-		labelStyle := lipgloss.NewStyle().Foreground(Grey)
-
-		shortcuts := fmt.Sprintf("%s%s%s %s  %s%s%s %s  %s%s%s %s  %s%s%s %s",
-			bracketStyle.Render("["), keyStyle.Render("E"), bracketStyle.Render("]"), labelStyle.Render("Edit"),
-			bracketStyle.Render("["), keyStyle.Render("C"), bracketStyle.Render("]"), labelStyle.Render("Contexts"),
-			bracketStyle.Render("["), keyStyle.Render("ESC"), bracketStyle.Render("]"), labelStyle.Render("Cancel"),
-			bracketStyle.Render("["), keyStyle.Render("↵"), bracketStyle.Render("]"), labelStyle.Render("Continue"))
-
-		fmt.Println(shortcuts)
-
-		keyboard.Listen(func(key keys.Key) (stop bool, err error) {
-			switch key.Code {
-			case keys.Escape:
-				resultAction = "cancel"
-				resultMessage = ""
-				return true, nil
-			case keys.Enter:
-				resultAction = "commit"
-				resultMessage = currentMessage
-				return true, nil
-			case keys.RuneKey:
-				if key.String() == "c" || key.String() == "C" {
-					showContexts("")
-					return false, nil
-				}
-				if key.String() == "e" || key.String() == "E" {
-					fmt.Print("\033[3A")
-					for range 3 {
-						fmt.Print("\033[2K\n")
-					}
-					fmt.Print("\033[3A")
-					edited, err := EditMessage(currentMessage)
-					if err != nil {
-						return true, nil
-					}
-					currentMessage = edited
-
-					fmt.Print("\033[3A")
-					for range 3 {
-						fmt.Print("\033[2K\n")
-					}
-					fmt.Print("\033[3A")
-
-					msgStyle := lipgloss.NewStyle().
-						Foreground(LightGrey).
-						MarginTop(1).
-						MarginBottom(1)
-
-					keyStyle := lipgloss.NewStyle().Foreground(White)
-					bracketStyle := lipgloss.NewStyle().Foreground(Grey)
-					labelStyle := lipgloss.NewStyle().Foreground(Grey)
-
-					newShortcuts := fmt.Sprintf("%s%s%s %s  %s%s%s %s  %s%s%s %s",
-						bracketStyle.Render("["), keyStyle.Render("E"), bracketStyle.Render("]"), labelStyle.Render("Edit"),
-						bracketStyle.Render("["), keyStyle.Render("ESC"), bracketStyle.Render("]"), labelStyle.Render("Cancel"),
-						bracketStyle.Render("["), keyStyle.Render("↵"), bracketStyle.Render("]"), labelStyle.Render("Continue"))
-
-					fmt.Println(msgStyle.Render(currentMessage))
-					fmt.Println(newShortcuts)
-
-					return true, nil
-				}
-			}
-			return false, nil
-		})
-
-		if resultAction != "" {
-			done = true
-		}
-	}
-
-	return resultAction, resultMessage
-}
-
-func showContexts(currentPath string) {
-	cm, err := config.LoadContexts()
-	if err != nil {
-		fmt.Println("\nErro ao carregar contextos")
-		return
-	}
-
-	contexts := cm.ListContexts(currentPath)
-	if len(contexts) == 0 {
-		fmt.Println("\nℹ Nenhum contexto ativo")
-		return
-	}
-
-	fmt.Println("\nContextos ativos:")
-	for _, ctx := range contexts {
-		fmt.Printf("  • %s\n", ctx.Text)
-	}
-}
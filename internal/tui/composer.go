@@ -0,0 +1,767 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	subjectSoftLimit = 50
+	subjectHardLimit = 72
+	maxFileLines     = 6
+	splitMinWidth    = 100
+	candidatesHeight = 6
+)
+
+var (
+	paneStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#505050"))
+
+	focusedPaneStyle = paneStyle.
+				BorderForeground(lipgloss.Color("#7D56F4"))
+
+	paneTitleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#E8E8E8"))
+
+	mutedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#808080"))
+
+	addedLineStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	removedLineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF9999"))
+	hunkLineStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4"))
+	fileLineStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#E8E8E8"))
+
+	gaugeOKStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	gaugeWarnStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFCC99"))
+	gaugeOverStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+
+	agentChipStyle = lipgloss.NewStyle().
+			Padding(0, 1).
+			Foreground(lipgloss.Color("#C0C0C0"))
+
+	activeAgentChipStyle = agentChipStyle.
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(lipgloss.Color("#7D56F4")).
+				Bold(true)
+
+	candidateStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#505050"))
+
+	selectedCandidateStyle = candidateStyle.
+				BorderForeground(lipgloss.Color("#04B575"))
+)
+
+type ComposerAction string
+
+const (
+	ComposerCommit ComposerAction = "commit"
+	ComposerCancel ComposerAction = "cancel"
+)
+
+type Candidate struct {
+	Agent   string
+	Model   string
+	Message string
+}
+
+type ComposerOptions struct {
+	Title       string
+	Message     string
+	Diff        string
+	DiffTitle   string
+	Files       []string
+	Agents      []string
+	Agent       string
+	Candidates  []Candidate
+	Cached      bool
+	Contexts    []string
+	UseContexts bool
+	Regenerate  func(agent string, useContexts bool) (string, error)
+	Refine      func(agent, message, feedback string) (string, error)
+}
+
+type ComposerKeyMap struct {
+	Accept      key.Binding
+	Cancel      key.Binding
+	SwitchFocus key.Binding
+	Regenerate  key.Binding
+	Editor      key.Binding
+	Contexts    key.Binding
+	Feedback    key.Binding
+}
+
+var DefaultComposerKeyMap = ComposerKeyMap{
+	Accept: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "accept"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("esc", "ctrl+c"),
+		key.WithHelp("esc", "cancel"),
+	),
+	SwitchFocus: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "switch pane"),
+	),
+	Regenerate: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "regenerate"),
+	),
+	Editor: key.NewBinding(
+		key.WithKeys("ctrl+e"),
+		key.WithHelp("ctrl+e", "$EDITOR"),
+	),
+	Contexts: key.NewBinding(
+		key.WithKeys("ctrl+t"),
+		key.WithHelp("ctrl+t", "contexts"),
+	),
+	Feedback: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+		key.WithHelp("ctrl+g", "feedback"),
+	),
+}
+
+type composerFocus int
+
+const (
+	focusEditor composerFocus = iota
+	focusCandidates
+	focusDiff
+)
+
+type regeneratedMsg struct {
+	message  string
+	agent    string
+	feedback bool
+	err      error
+}
+
+type editorFinishedMsg struct {
+	path string
+	err  error
+}
+
+type Composer struct {
+	opts           ComposerOptions
+	keys           ComposerKeyMap
+	editor         textarea.Model
+	diff           viewport.Model
+	spinner        spinner.Model
+	feedback       textinput.Model
+	focus          composerFocus
+	picking        bool
+	giveFeedback   bool
+	agentIndex     int
+	candidateIndex int
+	busy           bool
+	status         string
+	err            error
+	width          int
+	height         int
+	paneWidth      int
+	action         ComposerAction
+}
+
+func NewComposer(opts ComposerOptions) Composer {
+	if opts.Title == "" {
+		opts.Title = "Commit message"
+	}
+	if opts.DiffTitle == "" {
+		opts.DiffTitle = "Staged changes"
+	}
+
+	editor := textarea.New()
+	editor.ShowLineNumbers = false
+	editor.Prompt = ""
+	editor.CharLimit = 0
+	editor.MaxHeight = 0
+	editor.SetValue(opts.Message)
+	editor.Focus()
+
+	agentIndex := 0
+	for i, name := range opts.Agents {
+		if name == opts.Agent {
+			agentIndex = i
+		}
+	}
+
+	feedback := textinput.New()
+	feedback.Prompt = "Feedback: "
+	feedback.Placeholder = "e.g. mention the migration, shorter subject"
+
+	c := Composer{
+		opts:       opts,
+		keys:       DefaultComposerKeyMap,
+		editor:     editor,
+		diff:       viewport.New(80, 20),
+		spinner:    spinner.New(spinner.WithSpinner(spinner.Dot)),
+		feedback:   feedback,
+		agentIndex: agentIndex,
+		action:     ComposerCancel,
+	}
+	c.diff.SetContent(renderDiff(opts.Diff))
+	return c
+}
+
+func (c Composer) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (c Composer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.width = msg.Width
+		c.height = msg.Height
+		c.resize()
+		return c, nil
+
+	case spinner.TickMsg:
+		if !c.busy {
+			return c, nil
+		}
+		var cmd tea.Cmd
+		c.spinner, cmd = c.spinner.Update(msg)
+		return c, cmd
+
+	case regeneratedMsg:
+		c.busy = false
+		if msg.err != nil {
+			c.err = fmt.Errorf("failed to regenerate with %s: %w", msg.agent, msg.err)
+			return c, nil
+		}
+		c.opts.Agent = msg.agent
+		c.opts.Cached = false
+		c.editor.SetValue(msg.message)
+		c.status = fmt.Sprintf("Regenerated with %s", msg.agent)
+		if msg.feedback {
+			c.status = fmt.Sprintf("Revised by %s using your feedback", msg.agent)
+		}
+		return c, nil
+
+	case editorFinishedMsg:
+		if msg.path != "" {
+			defer os.Remove(msg.path)
+		}
+		if msg.err != nil {
+			c.err = fmt.Errorf("editor failed: %w", msg.err)
+			return c, nil
+		}
+		data, err := os.ReadFile(msg.path)
+		if err != nil {
+			c.err = fmt.Errorf("failed to read edited message: %w", err)
+			return c, nil
+		}
+		c.editor.SetValue(strings.TrimRight(string(data), "\n"))
+		c.status = "Message updated from editor"
+		return c, nil
+
+	case tea.KeyMsg:
+		if c.picking {
+			return c.updatePicker(msg)
+		}
+		if c.giveFeedback {
+			return c.updateFeedback(msg)
+		}
+
+		switch {
+		case key.Matches(msg, c.keys.Cancel):
+			c.action = ComposerCancel
+			return c, tea.Quit
+
+		case key.Matches(msg, c.keys.Accept):
+			if strings.TrimSpace(c.editor.Value()) == "" {
+				c.err = fmt.Errorf("message cannot be empty")
+				return c, nil
+			}
+			c.action = ComposerCommit
+			return c, tea.Quit
+
+		case key.Matches(msg, c.keys.SwitchFocus):
+			next := focusDiff
+			switch c.focus {
+			case focusEditor:
+				if len(c.opts.Candidates) > 1 {
+					next = focusCandidates
+				}
+			case focusDiff:
+				next = focusEditor
+			}
+			return c, c.setFocus(next)
+
+		case key.Matches(msg, c.keys.Feedback):
+			if c.opts.Refine == nil {
+				c.status = "Feedback is not available here"
+				return c, nil
+			}
+			if c.busy {
+				return c, nil
+			}
+			c.giveFeedback = true
+			c.feedback.Reset()
+			c.resize()
+			return c, c.feedback.Focus()
+
+		case key.Matches(msg, c.keys.Regenerate):
+			if c.opts.Regenerate == nil {
+				c.status = "Regeneration is not available here"
+				return c, nil
+			}
+			if c.busy {
+				return c, nil
+			}
+			if len(c.opts.Agents) > 1 {
+				c.picking = true
+				c.resize()
+				return c, nil
+			}
+			return c.startRegenerate(c.opts.Agent)
+
+		case key.Matches(msg, c.keys.Editor):
+			return c, c.openEditor()
+
+		case key.Matches(msg, c.keys.Contexts):
+			c.opts.UseContexts = !c.opts.UseContexts
+			if c.opts.UseContexts {
+				c.status = "Contexts enabled for regeneration"
+			} else {
+				c.status = "Contexts disabled for regeneration"
+			}
+			c.resize()
+			return c, nil
+		}
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && c.focus == focusCandidates {
+		return c.updateCandidates(msg)
+	}
+
+	var cmd tea.Cmd
+	if c.focus == focusEditor {
+		c.editor, cmd = c.editor.Update(msg)
+	} else {
+		c.diff, cmd = c.diff.Update(msg)
+	}
+	return c, cmd
+}
+
+func (c *Composer) setFocus(focus composerFocus) tea.Cmd {
+	c.focus = focus
+	if focus == focusEditor {
+		return c.editor.Focus()
+	}
+	c.editor.Blur()
+	return nil
+}
+
+func (c Composer) updateCandidates(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	count := len(c.opts.Candidates)
+	switch msg.String() {
+	case "left", "h", "up", "k":
+		c.candidateIndex = (c.candidateIndex - 1 + count) % count
+	case "right", "l", "down", "j":
+		c.candidateIndex = (c.candidateIndex + 1) % count
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if idx := int(msg.String()[0] - '1'); idx < count {
+			c.candidateIndex = idx
+		}
+	case "enter":
+		candidate := c.opts.Candidates[c.candidateIndex]
+		c.editor.SetValue(candidate.Message)
+		c.opts.Agent = candidate.Agent
+		c.status = fmt.Sprintf("Using candidate %d from %s", c.candidateIndex+1, candidate.Agent)
+		return c, c.setFocus(focusEditor)
+	}
+	return c, nil
+}
+
+func (c Composer) updateFeedback(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		c.giveFeedback = false
+		c.feedback.Blur()
+		c.resize()
+		return c, nil
+
+	case tea.KeyEnter:
+		text := strings.TrimSpace(c.feedback.Value())
+		c.giveFeedback = false
+		c.feedback.Blur()
+		c.resize()
+		if text == "" {
+			return c, nil
+		}
+		return c.startRefine(text)
+	}
+
+	var cmd tea.Cmd
+	c.feedback, cmd = c.feedback.Update(msg)
+	return c, cmd
+}
+
+func (c Composer) updatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "left", "shift+tab", "h":
+		c.agentIndex = (c.agentIndex - 1 + len(c.opts.Agents)) % len(c.opts.Agents)
+	case "right", "tab", "l":
+		c.agentIndex = (c.agentIndex + 1) % len(c.opts.Agents)
+	case "enter":
+		c.picking = false
+		c.resize()
+		return c.startRegenerate(c.opts.Agents[c.agentIndex])
+	case "esc", "ctrl+c":
+		c.picking = false
+		c.resize()
+	}
+	return c, nil
+}
+
+func (c Composer) startRegenerate(agent string) (tea.Model, tea.Cmd) {
+	c.busy = true
+	c.status, c.err = "", nil
+
+	regenerate, useContexts := c.opts.Regenerate, c.opts.UseContexts
+	run := func() tea.Msg {
+		message, err := regenerate(agent, useContexts)
+		return regeneratedMsg{message: message, agent: agent, err: err}
+	}
+	return c, tea.Batch(run, c.spinner.Tick)
+}
+
+func (c Composer) startRefine(feedback string) (tea.Model, tea.Cmd) {
+	c.busy = true
+	c.status, c.err = "", nil
+
+	refine, agent, message := c.opts.Refine, c.opts.Agent, c.editor.Value()
+	run := func() tea.Msg {
+		revised, err := refine(agent, message, feedback)
+		return regeneratedMsg{message: revised, agent: agent, feedback: true, err: err}
+	}
+	return c, tea.Batch(run, c.spinner.Tick)
+}
+
+func (c Composer) openEditor() tea.Cmd {
+	f, err := os.CreateTemp("", "gitscribe-message-*.txt")
+	if err != nil {
+		return func() tea.Msg { return editorFinishedMsg{err: err} }
+	}
+	path := f.Name()
+
+	_, err = f.WriteString(c.editor.Value() + "\n")
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return func() tea.Msg { return editorFinishedMsg{path: path, err: err} }
+	}
+
+	args := strings.Fields(editorCommand())
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{path: path, err: err}
+	})
+}
+
+func editorCommand() string {
+	for _, env := range []string{"GIT_EDITOR", "VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+func (c *Composer) resize() {
+	if c.width == 0 {
+		return
+	}
+
+	bodyHeight := c.height - 4
+	if c.picking || c.giveFeedback {
+		bodyHeight--
+	}
+	if len(c.opts.Candidates) > 1 {
+		bodyHeight -= candidatesHeight
+	}
+	if bodyHeight < 8 {
+		bodyHeight = 8
+	}
+
+	var leftWidth, leftHeight, rightWidth, rightHeight int
+	if c.width >= splitMinWidth {
+		leftWidth = c.width * 2 / 5
+		rightWidth = c.width - leftWidth
+		leftHeight, rightHeight = bodyHeight, bodyHeight
+	} else {
+		leftWidth, rightWidth = c.width, c.width
+		leftHeight = bodyHeight * 2 / 5
+		rightHeight = bodyHeight - leftHeight
+	}
+
+	c.diff.Width = leftWidth - 2
+	c.diff.Height = max(leftHeight-2-c.filesHeight(), 1)
+
+	c.paneWidth = rightWidth - 2
+	c.editor.SetWidth(c.paneWidth)
+	c.feedback.Width = c.width - len(c.feedback.Prompt) - 1
+	c.editor.SetHeight(max(rightHeight-2-2-c.contextsHeight(), 1))
+}
+
+func (c Composer) filesHeight() int {
+	if len(c.opts.Files) == 0 {
+		return 1
+	}
+	return min(len(c.opts.Files), maxFileLines) + 2
+}
+
+func (c Composer) contextsHeight() int {
+	if !c.opts.UseContexts {
+		return 0
+	}
+	return max(min(len(c.opts.Contexts), 3), 1) + 1
+}
+
+func (c Composer) View() string {
+	if c.width == 0 {
+		return ""
+	}
+
+	header := titleStyle.UnsetMarginBottom().Render(c.opts.Title)
+	if c.opts.Agent != "" {
+		header += mutedStyle.Render("  agent: " + c.opts.Agent)
+	}
+	if c.opts.Regenerate != nil {
+		header += mutedStyle.Render(fmt.Sprintf("  contexts: %s", onOff(c.opts.UseContexts)))
+	}
+	if c.opts.Cached {
+		header += "  " + gaugeWarnStyle.Render("cached response")
+	}
+
+	left := c.leftPane()
+	right := c.rightPane()
+
+	var body string
+	if c.width >= splitMinWidth {
+		body = lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+	} else {
+		body = lipgloss.JoinVertical(lipgloss.Left, left, right)
+	}
+
+	sections := []string{header}
+	if len(c.opts.Candidates) > 1 {
+		sections = append(sections, c.candidatesView())
+	}
+	sections = append(sections, body)
+	if c.picking {
+		sections = append(sections, c.pickerView())
+	}
+	if c.giveFeedback {
+		sections = append(sections, c.feedback.View())
+	}
+	sections = append(sections, c.statusView(), c.helpView())
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+func (c Composer) leftPane() string {
+	var lines []string
+	lines = append(lines, paneTitleStyle.Render(c.opts.DiffTitle))
+	if len(c.opts.Files) > 0 {
+		for i, file := range c.opts.Files {
+			if i == maxFileLines {
+				lines[len(lines)-1] = mutedStyle.Render(fmt.Sprintf("… %d more", len(c.opts.Files)-maxFileLines+1))
+				break
+			}
+			lines = append(lines, file)
+		}
+		lines = append(lines, "")
+	}
+	lines = append(lines, c.diff.View())
+
+	style := paneStyle
+	if c.focus == focusDiff {
+		style = focusedPaneStyle
+	}
+	return style.Width(c.diff.Width).Render(strings.Join(lines, "\n"))
+}
+
+func (c Composer) rightPane() string {
+	var lines []string
+	lines = append(lines, subjectGauge(c.subject()), "")
+	lines = append(lines, c.editor.View())
+
+	if c.opts.UseContexts {
+		lines = append(lines, mutedStyle.Render("Contexts:"))
+		if len(c.opts.Contexts) == 0 {
+			lines = append(lines, mutedStyle.Render("  none for this project"))
+		}
+		for i, ctx := range c.opts.Contexts {
+			if i == 3 {
+				break
+			}
+			lines = append(lines, mutedStyle.Render("  • "+ctx))
+		}
+	}
+
+	style := paneStyle
+	if c.focus == focusEditor {
+		style = focusedPaneStyle
+	}
+	return style.Width(c.paneWidth).Render(strings.Join(lines, "\n"))
+}
+
+func (c Composer) candidatesView() string {
+	count := len(c.opts.Candidates)
+	width := max(c.width/count-2, 10)
+
+	boxes := make([]string, 0, count)
+	for i, candidate := range c.opts.Candidates {
+		label := fmt.Sprintf("%d · %s", i+1, candidate.Agent)
+		if candidate.Model != "" && candidate.Model != candidate.Agent {
+			label += " · " + candidate.Model
+		}
+
+		style := candidateStyle
+		labelStyle := mutedStyle
+		if i == c.candidateIndex {
+			style = selectedCandidateStyle
+			if c.focus == focusCandidates {
+				labelStyle = statusStyle.Bold(true)
+			}
+		}
+
+		content := lipgloss.NewStyle().MaxWidth(width).Render(labelStyle.Render(label)) + "\n" +
+			lipgloss.NewStyle().Width(width).MaxHeight(candidatesHeight-3).Render(candidate.Message)
+		boxes = append(boxes, style.Width(width).Height(candidatesHeight-2).MaxHeight(candidatesHeight).Render(content))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, boxes...)
+}
+
+func (c Composer) pickerView() string {
+	chips := []string{mutedStyle.Render("Regenerate with:")}
+	for i, name := range c.opts.Agents {
+		if i == c.agentIndex {
+			chips = append(chips, activeAgentChipStyle.Render(name))
+			continue
+		}
+		chips = append(chips, agentChipStyle.Render(name))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, chips...)
+}
+
+func (c Composer) statusView() string {
+	switch {
+	case c.busy:
+		return c.spinner.View() + " Regenerating..."
+	case c.err != nil:
+		return errorStyle.Render(fmt.Sprintf("Error: %v", c.err))
+	case c.status != "":
+		return statusStyle.Render(c.status)
+	}
+	return ""
+}
+
+func (c Composer) helpView() string {
+	if c.picking {
+		return mutedStyle.Render("←/→: choose agent  •  enter: regenerate  •  esc: back")
+	}
+	if c.giveFeedback {
+		return mutedStyle.Render("enter: send feedback  •  esc: back")
+	}
+	if c.focus == focusCandidates {
+		return mutedStyle.Render("←/→ or 1-9: choose candidate  •  enter: use it  •  tab: switch pane  •  esc: cancel")
+	}
+	bindings := []key.Binding{c.keys.Accept, c.keys.SwitchFocus, c.keys.Editor}
+	if c.opts.Regenerate != nil {
+		bindings = append(bindings, c.keys.Regenerate, c.keys.Contexts)
+	}
+	if c.opts.Refine != nil {
+		bindings = append(bindings, c.keys.Feedback)
+	}
+	bindings = append(bindings, c.keys.Cancel)
+
+	var parts []string
+	for _, b := range bindings {
+		parts = append(parts, b.Help().Key+": "+b.Help().Desc)
+	}
+	return mutedStyle.Render(strings.Join(parts, "  •  "))
+}
+
+func (c Composer) subject() string {
+	subject, _, _ := strings.Cut(c.editor.Value(), "\n")
+	return subject
+}
+
+func (c Composer) Message() string {
+	return strings.TrimSpace(c.editor.Value())
+}
+
+func (c Composer) Action() ComposerAction {
+	return c.action
+}
+
+func subjectGauge(subject string) string {
+	const barWidth = 20
+
+	n := utf8.RuneCountInString(subject)
+	style := gaugeOKStyle
+	switch {
+	case n > subjectHardLimit:
+		style = gaugeOverStyle
+	case n > subjectSoftLimit:
+		style = gaugeWarnStyle
+	}
+
+	filled := min(n, subjectHardLimit) * barWidth / subjectHardLimit
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	return mutedStyle.Render("Subject ") + style.Render(fmt.Sprintf("%s %d/%d", bar, n, subjectHardLimit))
+}
+
+func renderDiff(diff string) string {
+	if strings.TrimSpace(diff) == "" {
+		return mutedStyle.Render("No diff to show")
+	}
+
+	lines := strings.Split(strings.ReplaceAll(diff, "\t", "    "), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git"), strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = fileLineStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunkLineStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = addedLineStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = removedLineStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+func RunComposer(opts ComposerOptions) (ComposerAction, string, error) {
+	result, err := tea.NewProgram(NewComposer(opts), tea.WithAltScreen()).Run()
+	if err != nil {
+		return ComposerCancel, "", err
+	}
+	composer := result.(Composer)
+	return composer.Action(), composer.Message(), nil
+}
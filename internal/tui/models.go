@@ -3,13 +3,15 @@ package tui
 import (
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
 	"github.com/albuquerquesz/gitscribe/internal/config"
-	"github.com/albuquerquesz/gitscribe/internal/models"
-	"github.com/albuquerquesz/gitscribe/internal/secrets"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -30,14 +32,17 @@ var (
 			Bold(true).
 			Foreground(lipgloss.Color("#3C3C3C")).
 			Background(lipgloss.Color("#D3D3D3")).
-			Padding(0, 1).
-			MarginTop(1)
+			Padding(0, 1)
 
 	defaultIndicator = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#FFD700")).
 				Bold(true).
 				Render(" ⭐ Default")
 
+	deprecatedIndicator = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FF9999")).
+				Render(" deprecated")
+
 	helpStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#808080")).
 			MarginTop(1)
@@ -62,10 +67,49 @@ var (
 	errorStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FF0000")).
 			Bold(true)
+
+	statusStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#04B575"))
+
+	warningStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFCC99"))
+
+	previewStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#505050")).
+			Padding(0, 1)
+
+	previewLabelStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#808080")).
+				Width(16)
 )
 
+const previewHeight = 7
+
+type KeyStore interface {
+	Load(provider string) (string, error)
+	Store(provider, apiKey string) (string, error)
+	Delete(provider string) error
+}
+
+type keyStoredMsg struct {
+	provider string
+	warning  string
+	err      error
+}
+
+type ProviderItem struct {
+	Provider   catalog.ProviderConfig
+	Configured bool
+	Count      int
+}
+
+func (i ProviderItem) Title() string       { return i.Provider.Title() }
+func (i ProviderItem) Description() string { return i.Provider.Description }
+func (i ProviderItem) FilterValue() string { return "" }
+
 type ModelItem struct {
-	Model      models.ModelInfo
+	Model      catalog.Model
 	Configured bool
 	IsDefault  bool
 }
@@ -73,13 +117,14 @@ type ModelItem struct {
 func (i ModelItem) Title() string       { return i.Model.Name }
 func (i ModelItem) Description() string { return i.Model.Description }
 func (i ModelItem) FilterValue() string {
-	return i.Model.Name + " " + i.Model.Provider + " " + i.Model.Description
+	return i.Model.Name + " " + i.Model.ID + " " + i.Model.Provider + " " + i.Model.Description
 }
 
 type KeyMap struct {
 	Select    key.Binding
 	Configure key.Binding
 	Remove    key.Binding
+	Preview   key.Binding
 	Quit      key.Binding
 	Help      key.Binding
 }
@@ -87,15 +132,19 @@ type KeyMap struct {
 var DefaultKeyMap = KeyMap{
 	Select: key.NewBinding(
 		key.WithKeys("enter"),
-		key.WithHelp("enter", "select"),
+		key.WithHelp("enter", "set default"),
 	),
 	Configure: key.NewBinding(
 		key.WithKeys("c"),
-		key.WithHelp("c", "configure"),
+		key.WithHelp("c", "configure key"),
 	),
 	Remove: key.NewBinding(
 		key.WithKeys("r"),
-		key.WithHelp("r", "remove"),
+		key.WithHelp("r", "remove key"),
+	),
+	Preview: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "preview"),
 	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "esc", "ctrl+c"),
@@ -107,65 +156,104 @@ var DefaultKeyMap = KeyMap{
 	),
 }
 
+type mode int
+
+const (
+	modeBrowse mode = iota
+	modeKeyInput
+	modeConfirmRemove
+)
+
 type Model struct {
 	list           list.Model
 	keys           KeyMap
+	keyStore       KeyStore
+	input          textinput.Model
+	mode           mode
+	target         string
 	configured     map[string]bool
 	currentDefault string
 	selectedItem   *ModelItem
+	status         string
+	warning        string
 	err            error
+	busy           bool
+	spinner        spinner.Model
 	showHelp       bool
+	showPreview    bool
 	width          int
 	height         int
 	quitting       bool
 }
 
-func NewModel(cfg *config.Config, keyMgr *secrets.AgentKeyManager) Model {
-
+func NewModel(cfg *config.Config, manager *catalog.CatalogManager, keyStore KeyStore) Model {
 	configured := make(map[string]bool)
-	for _, agent := range cfg.Agents {
-		if agent.Enabled {
-			configured[string(agent.Provider)] = true
+	var items []list.Item
+
+	for _, providerKey := range manager.ListProviders() {
+		modelList := manager.GetModelsByProvider(providerKey)
+		if len(modelList) == 0 {
+			continue
 		}
-	}
 
-	var items []list.Item
-	providerKeys := models.GetProviderKeys()
+		pConfig, _ := manager.GetProviderConfig(providerKey)
+		configured[providerKey] = hasCredentials(pConfig, keyStore)
 
-	for _, providerKey := range providerKeys {
-		modelList := models.GetModelsForProvider(providerKey)
+		items = append(items, ProviderItem{
+			Provider:   pConfig,
+			Configured: configured[providerKey],
+			Count:      len(modelList),
+		})
 
 		for _, m := range modelList {
-			isDefault := cfg.Global.DefaultAgent == models.GenerateProfileName(providerKey, m.ID)
-
-			item := ModelItem{
+			items = append(items, ModelItem{
 				Model:      m,
 				Configured: configured[providerKey],
-				IsDefault:  isDefault,
-			}
-			items = append(items, item)
+				IsDefault:  cfg.Global.DefaultAgent == catalog.ProfileName(providerKey, m.ID),
+			})
 		}
 	}
 
 	l := list.New(items, modelDelegate{}, 80, 20)
 	l.Title = "AI Models"
+	l.SetShowTitle(false)
 	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
 	l.SetFilteringEnabled(true)
 	l.Styles.Title = titleStyle
-	l.AdditionalShortHelpKeys = func() []key.Binding {
-		return []key.Binding{
-			DefaultKeyMap.Configure,
-			DefaultKeyMap.Remove,
-		}
-	}
 
-	return Model{
+	input := textinput.New()
+	input.EchoMode = textinput.EchoPassword
+	input.EchoCharacter = '•'
+	input.Prompt = "API key: "
+
+	m := Model{
 		list:           l,
 		keys:           DefaultKeyMap,
+		keyStore:       keyStore,
+		input:          input,
 		configured:     configured,
 		currentDefault: cfg.Global.DefaultAgent,
 		showHelp:       true,
+		showPreview:    true,
+		spinner:        spinner.New(spinner.WithSpinner(spinner.Dot)),
+	}
+	m.skipProviderRows(0)
+	return m
+}
+
+func hasCredentials(p catalog.ProviderConfig, keyStore KeyStore) bool {
+	if !p.RequiresAPIKey() {
+		return true
+	}
+	if p.EnvVar != "" && os.Getenv(p.EnvVar) != "" {
+		return true
 	}
+	if keyStore == nil {
+		return false
+	}
+	apiKey, err := keyStore.Load(p.Name)
+	return err == nil && apiKey != ""
 }
 
 type modelDelegate struct{}
@@ -176,42 +264,39 @@ func (d modelDelegate) Spacing() int { return 0 }
 func (d modelDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd { return nil }
 
 func (d modelDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
-	i, ok := item.(ModelItem)
-	if !ok {
-		return
-	}
-
-	var lines []string
-
-	cursor := "  "
-	if index == m.Index() {
-		cursor = selectedStyle.Render("❯ ")
-	}
-
-	nameStr := unconfiguredNameStyle.Render(i.Model.Name)
-	if i.Configured {
-		nameStr = configuredNameStyle.Render(i.Model.Name)
-	}
-
-	firstLine := cursor + nameStr
+	switch i := item.(type) {
+	case ProviderItem:
+		header := providerStyle.Render(strings.TrimSpace(i.Provider.Icon + " " + i.Provider.Title()))
+		if i.Configured {
+			header += "  " + configuredIndicator
+		}
+		fmt.Fprintf(w, "\n%s", header)
 
-	if i.Configured {
-		firstLine += "  " + configuredIndicator
-	}
+	case ModelItem:
+		cursor := "  "
+		if index == m.Index() {
+			cursor = selectedStyle.Render("❯ ")
+		}
 
-	if i.IsDefault {
-		firstLine += defaultIndicator
-	}
+		nameStyle, descStyle := unconfiguredNameStyle, unconfiguredDescStyle
+		if i.Configured {
+			nameStyle, descStyle = configuredNameStyle, configuredDescStyle
+		}
 
-	lines = append(lines, firstLine)
+		firstLine := cursor + nameStyle.Render(i.Model.Name)
+		if i.IsDefault {
+			firstLine += defaultIndicator
+		}
+		if i.Model.IsDeprecated() {
+			firstLine += deprecatedIndicator
+		}
 
-	descStr := unconfiguredDescStyle.Render("    " + i.Model.Description)
-	if i.Configured {
-		descStr = configuredDescStyle.Render("    " + i.Model.Description)
+		desc := i.Model.Description
+		if desc == "" {
+			desc = i.Model.ID
+		}
+		fmt.Fprint(w, firstLine+"\n"+descStyle.Render("    "+desc))
 	}
-	lines = append(lines, descStr)
-
-	fmt.Fprint(w, strings.Join(lines, "\n"))
 }
 
 func (m Model) Init() tea.Cmd {
@@ -223,13 +308,52 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.list.SetWidth(msg.Width)
-		m.list.SetHeight(msg.Height - 6)
+		m.resize()
+		return m, nil
+
+	case spinner.TickMsg:
+		if !m.busy {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case keyStoredMsg:
+		m.busy = false
+		if msg.err != nil {
+			m.err = fmt.Errorf("failed to store API key: %w", msg.err)
+			return m, nil
+		}
+		m.setConfigured(msg.provider, true)
+		m.status = fmt.Sprintf("API key saved for %s", msg.provider)
+		m.warning = msg.warning
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.busy {
+			if msg.Type == tea.KeyCtrlC {
+				m.quitting = true
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		switch m.mode {
+		case modeKeyInput:
+			return m.updateKeyInput(msg)
+		case modeConfirmRemove:
+			return m.updateConfirmRemove(msg)
+		}
+
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+
 		switch {
 		case key.Matches(msg, m.keys.Quit):
+			if msg.String() == "esc" && m.list.FilterState() == list.FilterApplied {
+				break
+			}
 			m.quitting = true
 			return m, tea.Quit
 
@@ -241,24 +365,170 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, m.keys.Configure):
-
+			if provider, ok := m.selectedProvider(); ok {
+				if !provider.RequiresAPIKey() {
+					m.status = fmt.Sprintf("%s does not need an API key", provider.Title())
+					return m, nil
+				}
+				m.mode = modeKeyInput
+				m.target = provider.Name
+				m.status, m.warning, m.err = "", "", nil
+				m.input.Reset()
+				return m, m.input.Focus()
+			}
 			return m, nil
 
 		case key.Matches(msg, m.keys.Remove):
+			if provider, ok := m.selectedProvider(); ok {
+				if !provider.RequiresAPIKey() {
+					m.status = fmt.Sprintf("%s does not use an API key", provider.Title())
+					return m, nil
+				}
+				m.mode = modeConfirmRemove
+				m.target = provider.Name
+				m.status, m.warning, m.err = "", "", nil
+			}
+			return m, nil
 
+		case key.Matches(msg, m.keys.Preview):
+			m.showPreview = !m.showPreview
+			m.resize()
 			return m, nil
 
 		case key.Matches(msg, m.keys.Help):
 			m.showHelp = !m.showHelp
+			m.resize()
 			return m, nil
 		}
 	}
 
+	prev := m.list.Index()
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
+	m.skipProviderRows(prev)
 	return m, cmd
 }
 
+func (m Model) updateKeyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.mode = modeBrowse
+		m.input.Blur()
+		return m, nil
+
+	case tea.KeyEnter:
+		apiKey := strings.TrimSpace(m.input.Value())
+		m.mode = modeBrowse
+		m.input.Blur()
+		m.input.Reset()
+		if apiKey == "" {
+			m.err = fmt.Errorf("API key cannot be empty")
+			return m, nil
+		}
+		m.busy = true
+		m.status, m.warning, m.err = "", "", nil
+
+		keyStore, provider := m.keyStore, m.target
+		store := func() tea.Msg {
+			warning, err := keyStore.Store(provider, apiKey)
+			return keyStoredMsg{provider: provider, warning: warning, err: err}
+		}
+		return m, tea.Batch(store, m.spinner.Tick)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) updateConfirmRemove(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mode = modeBrowse
+	if msg.String() != "y" && msg.String() != "Y" {
+		return m, nil
+	}
+
+	if err := m.keyStore.Delete(m.target); err != nil {
+		m.err = fmt.Errorf("failed to remove API key: %w", err)
+		return m, nil
+	}
+	pConfig, _ := catalog.GetProviderConfig(m.target)
+	m.setConfigured(m.target, hasCredentials(pConfig, m.keyStore))
+	m.status, m.warning = fmt.Sprintf("API key removed for %s", m.target), ""
+	return m, nil
+}
+
+func (m *Model) selectedProvider() (catalog.ProviderConfig, bool) {
+	var name string
+	switch i := m.list.SelectedItem().(type) {
+	case ModelItem:
+		name = i.Model.Provider
+	case ProviderItem:
+		name = i.Provider.Name
+	default:
+		return catalog.ProviderConfig{}, false
+	}
+	return catalog.GetProviderConfig(name)
+}
+
+func (m *Model) setConfigured(provider string, configured bool) {
+	m.configured[provider] = configured
+	items := m.list.Items()
+	for idx, item := range items {
+		switch i := item.(type) {
+		case ProviderItem:
+			if i.Provider.Name == provider {
+				i.Configured = configured
+				items[idx] = i
+			}
+		case ModelItem:
+			if i.Model.Provider == provider {
+				i.Configured = configured
+				items[idx] = i
+			}
+		}
+	}
+	m.list.SetItems(items)
+}
+
+func (m *Model) skipProviderRows(prev int) {
+	visible := len(m.list.VisibleItems())
+	for range visible {
+		if _, ok := m.list.SelectedItem().(ProviderItem); !ok {
+			return
+		}
+		idx := m.list.Index()
+		if (idx < prev && idx > 0) || idx == visible-1 {
+			m.list.CursorUp()
+		} else {
+			m.list.CursorDown()
+		}
+		if m.list.Index() == idx {
+			return
+		}
+	}
+}
+
+func (m *Model) resize() {
+	if m.width == 0 {
+		return
+	}
+	m.list.SetWidth(m.width)
+	m.input.Width = m.width - len(m.input.Prompt) - 2
+
+	reserved := 6
+	if m.showPreview {
+		reserved += previewHeight + 2
+	}
+	if m.showHelp {
+		reserved += 2
+	}
+	height := m.height - reserved
+	if height < 4 {
+		height = 4
+	}
+	m.list.SetHeight(height)
+}
+
 func (m Model) View() string {
 	if m.quitting {
 		return ""
@@ -267,35 +537,39 @@ func (m Model) View() string {
 	var s strings.Builder
 
 	s.WriteString(titleStyle.Render("🤖 AI Model Selector"))
-	s.WriteString("\n\n")
-
-	s.WriteString(lipgloss.NewStyle().Bold(true).Render("Configured Providers:"))
 	s.WriteString("\n")
 
-	hasConfigured := false
-	for provider, isConfigured := range m.configured {
-		if isConfigured {
-			p := models.Providers[provider]
-			s.WriteString(configuredStyle.Render(fmt.Sprintf("✓ %s %s", p.Icon, p.DisplayName)))
-			s.WriteString("\n")
-			hasConfigured = true
-		}
+	s.WriteString(m.list.View())
+
+	if m.showPreview {
+		s.WriteString("\n")
+		s.WriteString(m.previewView())
 	}
 
-	if !hasConfigured {
-		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#808080")).Render("  None configured yet"))
+	switch m.mode {
+	case modeKeyInput:
 		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Configure %s", m.target)))
+		s.WriteString("\n")
+		s.WriteString(m.input.View())
+	case modeConfirmRemove:
+		s.WriteString("\n")
+		s.WriteString(errorStyle.Render(fmt.Sprintf("Remove the stored API key for %s? (y/N)", m.target)))
 	}
 
-	s.WriteString("\n")
+	if m.busy {
+		s.WriteString("\n")
+		s.WriteString(m.spinner.View() + fmt.Sprintf(" Verifying the API key for %s...", m.target))
+	}
 
-	s.WriteString(m.list.View())
+	if m.status != "" {
+		s.WriteString("\n")
+		s.WriteString(statusStyle.Render(m.status))
+	}
 
-	if m.showHelp {
+	if m.warning != "" {
 		s.WriteString("\n")
-		s.WriteString(helpStyle.Render(
-			"enter: select  •  c: configure  •  r: remove  •  /: filter  •  ?: help  •  q: quit",
-		))
+		s.WriteString(warningStyle.Render("Warning: " + m.warning))
 	}
 
 	if m.err != nil {
@@ -303,9 +577,81 @@ func (m Model) View() string {
 		s.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
 	}
 
+	if m.showHelp {
+		s.WriteString("\n")
+		s.WriteString(helpStyle.Render(
+			"enter: set default  •  c: configure key  •  r: remove key  •  p: preview  •  /: filter  •  ?: help  •  q: quit",
+		))
+	}
+
 	return s.String()
 }
 
+func (m Model) previewView() string {
+	item, ok := m.list.SelectedItem().(ModelItem)
+	if !ok {
+		return previewStyle.Render("No model selected")
+	}
+	model := item.Model
+
+	row := func(label, value string) string {
+		return previewLabelStyle.Render(label) + value
+	}
+
+	price := "unknown"
+	if model.InputPrice > 0 || model.OutputPrice > 0 {
+		price = fmt.Sprintf("$%.2f in / $%.2f out per 1M tokens", model.InputPrice, model.OutputPrice)
+	}
+
+	var features []string
+	if model.SupportsStreaming {
+		features = append(features, "streaming")
+	}
+	if model.SupportsJSON {
+		features = append(features, "json")
+	}
+	if len(features) == 0 {
+		features = append(features, "-")
+	}
+
+	status := "active"
+	if model.DeprecatedAt != "" {
+		status = "deprecated on " + model.DeprecatedAt
+		if !model.IsDeprecated() {
+			status = "deprecates on " + model.DeprecatedAt
+		}
+	}
+
+	lines := []string{
+		row("Model", fmt.Sprintf("%s (%s)", model.ID, model.Provider)),
+		row("Context window", formatTokens(model.ContextWindow)),
+		row("Max output", formatTokens(model.MaxOutput)),
+		row("Pricing", price),
+		row("Features", strings.Join(features, ", ")),
+		row("Status", status),
+		row("Profile", catalog.ProfileName(model.Provider, model.ID)),
+	}
+
+	width := m.width - 4
+	if width < 40 {
+		width = 40
+	}
+	return previewStyle.Width(width).Render(strings.Join(lines, "\n"))
+}
+
+func formatTokens(n int) string {
+	switch {
+	case n == 0:
+		return "unknown"
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM tokens", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%dK tokens", n/1_000)
+	default:
+		return fmt.Sprintf("%d tokens", n)
+	}
+}
+
 func (m Model) GetSelected() *ModelItem {
 	return m.selectedItem
 }
@@ -0,0 +1,217 @@
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/albuquerquesz/gitscribe/internal/catalog"
+	"github.com/albuquerquesz/gitscribe/internal/config"
+)
+
+const ledgerFileName = "usage.jsonl"
+
+var (
+	mu             sync.Mutex
+	currentCommand string
+	currentRepo    string
+)
+
+type Entry struct {
+	Time             time.Time `json:"time"`
+	Repo             string    `json:"repo,omitempty"`
+	Command          string    `json:"command,omitempty"`
+	Agent            string    `json:"agent"`
+	Provider         string    `json:"provider"`
+	Model            string    `json:"model"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	TotalTokens      int       `json:"total_tokens"`
+	LatencyMS        int64     `json:"latency_ms"`
+	Cost             float64   `json:"cost"`
+}
+
+type Total struct {
+	Key              string
+	Requests         int
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	Cost             float64
+}
+
+type BudgetStatus struct {
+	Agent  string
+	Budget float64
+	Spent  float64
+}
+
+func (b BudgetStatus) Exceeded() bool {
+	return b.Budget > 0 && b.Spent >= b.Budget
+}
+
+func SetContext(command, repo string) {
+	mu.Lock()
+	defer mu.Unlock()
+	currentCommand = command
+	currentRepo = repo
+}
+
+func GetLedgerPath() (string, error) {
+	dir, err := config.EnsureConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ledgerFileName), nil
+}
+
+func NewEntry(profile config.AgentProfile, promptTokens, completionTokens, totalTokens int, latency time.Duration) Entry {
+	if totalTokens == 0 {
+		totalTokens = promptTokens + completionTokens
+	}
+
+	var cost float64
+	if model, ok := catalog.LookupModel(string(profile.Provider), profile.Model); ok {
+		cost = model.EstimateCost(promptTokens, completionTokens)
+	}
+
+	mu.Lock()
+	command, repo := currentCommand, currentRepo
+	mu.Unlock()
+
+	return Entry{
+		Time:             time.Now(),
+		Repo:             repo,
+		Command:          command,
+		Agent:            profile.Name,
+		Provider:         string(profile.Provider),
+		Model:            profile.Model,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      totalTokens,
+		LatencyMS:        latency.Milliseconds(),
+		Cost:             cost,
+	}
+}
+
+func Record(entry Entry) error {
+	path, err := GetLedgerPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode usage entry: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open usage ledger: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write usage ledger: %w", err)
+	}
+	return nil
+}
+
+func Load(since time.Time) ([]Entry, error) {
+	path, err := GetLedgerPath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open usage ledger: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if e.Time.Before(since) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage ledger: %w", err)
+	}
+	return entries, nil
+}
+
+func Summarize(entries []Entry, key func(Entry) string) []Total {
+	totals := make(map[string]*Total)
+	for _, e := range entries {
+		k := key(e)
+		t, ok := totals[k]
+		if !ok {
+			t = &Total{Key: k}
+			totals[k] = t
+		}
+		t.Requests++
+		t.PromptTokens += e.PromptTokens
+		t.CompletionTokens += e.CompletionTokens
+		t.TotalTokens += e.TotalTokens
+		t.Cost += e.Cost
+	}
+
+	result := make([]Total, 0, len(totals))
+	for _, t := range totals {
+		result = append(result, *t)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+func ByDay(e Entry) string   { return e.Time.Local().Format("2006-01-02") }
+func ByAgent(e Entry) string { return e.Agent }
+func ByRepo(e Entry) string {
+	if e.Repo == "" {
+		return "(outside a repository)"
+	}
+	return e.Repo
+}
+
+func StartOfMonth(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+}
+
+func CheckBudget(profile config.AgentProfile) (BudgetStatus, error) {
+	status := BudgetStatus{Agent: profile.Name, Budget: profile.MonthlyBudget}
+	if profile.MonthlyBudget <= 0 {
+		return status, nil
+	}
+
+	entries, err := Load(StartOfMonth(time.Now()))
+	if err != nil {
+		return status, err
+	}
+	for _, e := range entries {
+		if e.Agent == profile.Name {
+			status.Spent += e.Cost
+		}
+	}
+	return status, nil
+}